@@ -2,17 +2,20 @@ module github.com/amecky/go-imgui
 
 go 1.22.2
 
-replace (
-	github.com/amecky/table => ../report-table
-)
+replace github.com/amecky/table => ../report-table
 
 require (
+	github.com/BurntSushi/toml v1.4.0
+	github.com/alecthomas/assert v1.0.0
 	github.com/amecky/table v0.0.0-00010101000000-000000000000
-	github.com/alecthomas/assert v1.0.0 // indirect
+	github.com/charmbracelet/bubbletea v1.2.4
+	github.com/rivo/uniseg v0.4.7
+)
+
+require (
 	github.com/alecthomas/colour v0.1.0 // indirect
 	github.com/alecthomas/repr v0.0.0-20210801044451-80ca428c5142 // indirect
 	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
-	github.com/charmbracelet/bubbletea v1.2.4 // indirect
 	github.com/charmbracelet/lipgloss v1.0.0 // indirect
 	github.com/charmbracelet/x/ansi v0.4.5 // indirect
 	github.com/charmbracelet/x/term v0.2.1 // indirect
@@ -24,7 +27,6 @@ require (
 	github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
 	github.com/muesli/cancelreader v0.2.2 // indirect
 	github.com/muesli/termenv v0.15.2 // indirect
-	github.com/rivo/uniseg v0.4.7 // indirect
 	github.com/sergi/go-diff v1.2.0 // indirect
 	golang.org/x/sync v0.9.0 // indirect
 	golang.org/x/sys v0.27.0 // indirect
@@ -35,7 +35,7 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 	case tea.WindowSizeMsg:
 		log.Println("Resizing  == > X", msg.Width, "Y", msg.Height)
-		m.gui = NewGUI(msg.Width, msg.Height)
+		m.gui = m.gui.resize(msg.Width, msg.Height)
 	case tea.MouseMsg:
 		mouseEvent := tea.MouseEvent(msg)
 		if m.gui != nil && mouseEvent.Action == tea.MouseActionMotion {
@@ -44,6 +44,9 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.gui != nil && mouseEvent.Action != tea.MouseActionMotion && mouseEvent.Action == tea.MouseActionPress {
 			m.gui.SetMouseEvent(mouseEvent)
 		}
+		if m.gui != nil && mouseEvent.Action == tea.MouseActionRelease {
+			m.gui.SetMouseRelease(mouseEvent)
+		}
 		if mouseEvent.Action != tea.MouseActionMotion && mouseEvent.Action == tea.MouseActionPress {
 			log.Printf("%+v at %d %d\n", mouseEvent, mouseEvent.X, mouseEvent.Y)
 		}
@@ -51,6 +54,17 @@ func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// resize creates a GUI of the new size which keeps the theme and
+// border of the old one
+func (g *GUI) resize(w, h int) *GUI {
+	ret := NewGUI(w, h)
+	if g != nil {
+		ret.buffer.theme = g.buffer.theme
+		ret.buffer.borders = g.buffer.borders
+	}
+	return ret
+}
+
 // View renders the UI
 func (m appModel) View() string {
 	if m.gui != nil {
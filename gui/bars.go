@@ -0,0 +1,234 @@
+package imgui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/amecky/table/table"
+)
+
+type Orientation int
+
+const (
+	HORIZONTAL Orientation = iota
+	VERTICAL
+)
+
+// fractional blocks from 1/8 to 7/8 of a cell width
+var PARTIAL_BLOCKS = []rune{'▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// Bar is one entry of a BarChart. If Color is empty the
+// color is taken from the foreground of SERIES_ROLES
+type Bar struct {
+	Label string
+	Value float64
+	Color string
+}
+
+// hbar returns a horizontal bar of length cells using fractional blocks
+func hbar(length float64) string {
+	if length <= 0 || !finite(length) {
+		return ""
+	}
+	n := int(math.Round(length * 8))
+	ret := strings.Repeat("█", n/8)
+	if n%8 > 0 {
+		ret += string(PARTIAL_BLOCKS[n%8-1])
+	}
+	return ret
+}
+
+// vblock returns the block character for a cell of a vertical bar
+// which is filled by fill (0..1)
+func vblock(fill float64) rune {
+	if !finite(fill) {
+		return ' '
+	}
+	if fill >= 1 {
+		return '█'
+	}
+	idx := int(math.Round(fill*8)) - 1
+	if idx < 0 {
+		return ' '
+	}
+	return SPARK_BLOCKS[idx]
+}
+
+func barMax(bars []Bar) float64 {
+	mx := 0.0
+	for _, b := range bars {
+		if finite(b.Value) {
+			mx = math.Max(mx, b.Value)
+		}
+	}
+	if mx == 0 {
+		mx = 1
+	}
+	return mx
+}
+
+// BarChart draws the bars either horizontal or vertical. Size is the length
+// of the longest bar in cells. Every bar is annotated with its value. Bars
+// without a finite value only show the label and the value
+func (g *GUI) BarChart(label string, bars []Bar, orientation Orientation, size int) {
+	g.buffer.PushID("BARCHART_" + label)
+	if label != "" {
+		g.buffer.Write(label, ROLE_HEADER, false)
+	}
+	mx := barMax(bars)
+	if orientation == HORIZONTAL {
+		labels := make([]string, len(bars))
+		for i, b := range bars {
+			labels[i] = b.Label
+		}
+		lw := findMaxLen(labels)
+		for i, b := range bars {
+			g.buffer.Write(formatString(b.Label, lw, table.AlignLeft)+" ", 0, true)
+			bar := hbar(b.Value / mx * float64(size))
+			if bar != "" {
+				g.buffer.Write(bar, seriesStyle(g.buffer.theme, b.Color, i), true)
+			}
+			g.buffer.Write(fmt.Sprintf(" %.2f", b.Value), 0, false)
+		}
+	} else {
+		values := make([]string, len(bars))
+		bw := 1
+		for i, b := range bars {
+			values[i] = fmt.Sprintf("%.2f", b.Value)
+			bw = max(bw, internalLen(values[i]), internalLen(b.Label))
+		}
+		heights := make([]float64, len(bars))
+		for i, b := range bars {
+			if finite(b.Value) {
+				heights[i] = math.Max(0, b.Value/mx*float64(size))
+			}
+		}
+		for y := size; y >= 0; y-- {
+			for i, b := range bars {
+				if i > 0 {
+					g.buffer.Write(" ", 0, true)
+				}
+				h := heights[i]
+				// the value is written right above the bar
+				if y == int(math.Ceil(h)) {
+					g.buffer.Write(formatString(values[i], bw, table.AlignCenter), 0, true)
+					continue
+				}
+				g.buffer.Write(strings.Repeat(string(vblock(h-float64(y))), bw), seriesStyle(g.buffer.theme, b.Color, i), true)
+			}
+			g.buffer.Write("", 0, false)
+		}
+		for i, b := range bars {
+			if i > 0 {
+				g.buffer.Write(" ", 0, true)
+			}
+			g.buffer.Write(formatString(b.Label, bw, table.AlignCenter), 0, true)
+		}
+		g.buffer.Write("", 0, false)
+	}
+	g.buffer.PopID()
+}
+
+// binSamples counts the finite samples in bins of equal size between the
+// minimum and maximum sample. Returns the counts and the lower bound
+// of every bin
+func binSamples(samples []float64, bins int) ([]int, []float64) {
+	counts := make([]int, bins)
+	bounds := make([]float64, bins)
+	mn, mx := minMax(samples)
+	step := (mx - mn) / float64(bins)
+	for i := range bounds {
+		bounds[i] = mn + step*float64(i)
+	}
+	for _, s := range samples {
+		if !finite(s) {
+			continue
+		}
+		idx := bins - 1
+		if step > 0 {
+			idx = int((s - mn) / step)
+		}
+		if idx >= bins {
+			idx = bins - 1
+		}
+		counts[idx]++
+	}
+	return counts, bounds
+}
+
+// Histogram sorts the samples into bins and draws the counts as
+// horizontal bar chart
+func (g *GUI) Histogram(label string, samples []float64, bins int, size int) {
+	if bins < 1 {
+		bins = 1
+	}
+	counts, bounds := binSamples(samples, bins)
+	bars := make([]Bar, bins)
+	for i := range bars {
+		bars[i] = Bar{
+			Label: fmt.Sprintf("%.2f", bounds[i]),
+			Value: float64(counts[i]),
+		}
+	}
+	g.BarChart(label, bars, HORIZONTAL, size)
+}
+
+// clampFraction limits the fraction to 0..1. A fraction which is
+// not finite like the result of 0/0 is treated as 0
+func clampFraction(fraction float64) float64 {
+	if !finite(fraction) {
+		return 0
+	}
+	return math.Max(0, math.Min(1, fraction))
+}
+
+// ProgressBar draws a bar of width cells filled by fraction (0..1). The
+// optional overlay text is centered on top of the bar. The bar has the
+// background of the button and the track the background of code
+func (g *GUI) ProgressBar(fraction float64, width int, overlay string) {
+	if width <= 0 {
+		return
+	}
+	g.buffer.PushID("PROGRESS_" + overlay)
+	fraction = clampFraction(fraction)
+	filled := fraction * float64(width)
+	runes := []rune(hbar(filled))
+	for len(runes) < width {
+		runes = append(runes, ' ')
+	}
+	styles := make([]int, width)
+	t := g.buffer.theme
+	track := t.Code.background
+	barSt := AddStyle(Style{foreground: t.Button.background, background: track, flags: ATTR_FOREGROUND | ATTR_BACKGROUND})
+	for i := range styles {
+		styles[i] = barSt
+	}
+	if overlay != "" {
+		ov := []rune(formatString(overlay, width, table.AlignCenter))
+		fillSt := AddStyle(t.Button.Bold())
+		emptySt := AddStyle(t.Code.Bold())
+		for i := 0; i < width && i < len(ov); i++ {
+			if ov[i] == ' ' {
+				continue
+			}
+			runes[i] = ov[i]
+			if float64(i)+0.5 <= filled {
+				styles[i] = fillSt
+			} else {
+				styles[i] = emptySt
+			}
+		}
+	}
+	writeRuns(g.buffer, runes, styles)
+	g.buffer.Write("", 0, false)
+	g.buffer.PopID()
+}
+
+// Gauge draws the label followed by a progress bar and the percentage
+func (g *GUI) Gauge(label string, fraction float64, width int) {
+	g.buffer.PushID("GAUGE_" + label)
+	g.label(label)
+	g.ProgressBar(fraction, width, fmt.Sprintf("%.0f%%", clampFraction(fraction)*100))
+	g.buffer.PopID()
+}
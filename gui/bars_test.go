@@ -0,0 +1,85 @@
+package imgui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestHBar(t *testing.T) {
+	assert.Equal(t, "", hbar(0))
+	assert.Equal(t, "██", hbar(2))
+	assert.Equal(t, "█▌", hbar(1.5))
+	assert.Equal(t, "▏", hbar(0.125))
+	assert.Equal(t, "", hbar(math.NaN()))
+	assert.Equal(t, "", hbar(math.Inf(1)))
+}
+
+func TestVBlock(t *testing.T) {
+	assert.Equal(t, '█', vblock(1.5))
+	assert.Equal(t, '▄', vblock(0.5))
+	assert.Equal(t, ' ', vblock(0))
+	assert.Equal(t, ' ', vblock(math.NaN()))
+}
+
+func TestBinSamples(t *testing.T) {
+	counts, bounds := binSamples([]float64{0, 1, 2, 3, 4, 10}, 2)
+	assert.Equal(t, []int{5, 1}, counts)
+	assert.Equal(t, []float64{0, 5}, bounds)
+
+	counts, bounds = binSamples([]float64{0, math.Inf(1), 4, math.NaN(), math.Inf(-1), 10}, 2)
+	assert.Equal(t, []int{2, 1}, counts)
+	assert.Equal(t, []float64{0, 5}, bounds)
+}
+
+func TestClampFraction(t *testing.T) {
+	assert.Equal(t, 0.0, clampFraction(math.NaN()))
+	assert.Equal(t, 0.0, clampFraction(math.Inf(1)))
+	assert.Equal(t, 0.0, clampFraction(-2))
+	assert.Equal(t, 1.0, clampFraction(2))
+	assert.Equal(t, 0.5, clampFraction(0.5))
+}
+
+func TestProgressBarNaN(t *testing.T) {
+	gui := NewGUI(30, 4)
+	gui.Begin()
+	zero := 0.0
+	gui.ProgressBar(math.NaN(), 10, "")
+	gui.Gauge("q", zero/zero, 10)
+	gui.End()
+	r, _ := gui.buffer.At(1, 1)
+	assert.Equal(t, ' ', r)
+}
+
+func TestBarChartNonFinite(t *testing.T) {
+	bars := []Bar{{Label: "a", Value: 2}, {Label: "b", Value: math.NaN()}, {Label: "c", Value: math.Inf(1)}}
+	gui := NewGUI(40, 12)
+	gui.Begin()
+	gui.BarChart("", bars, HORIZONTAL, 4)
+	gui.BarChart("", bars, VERTICAL, 4)
+	gui.Histogram("", []float64{1, math.Inf(1), 2}, 2, 4)
+	gui.End()
+	assert.Equal(t, 2.0, barMax(bars))
+}
+
+func TestProgressBarOverlay(t *testing.T) {
+	gui := NewGUI(20, 4)
+	gui.Begin()
+	gui.ProgressBar(0.5, 10, "50%")
+	gui.End()
+	r, _ := gui.buffer.At(1, 1)
+	assert.Equal(t, '█', r)
+	r, _ = gui.buffer.At(4, 1)
+	assert.Equal(t, '5', r)
+	r, _ = gui.buffer.At(10, 1)
+	assert.Equal(t, ' ', r)
+}
+
+func TestProgressBarNegativeWidth(t *testing.T) {
+	gui := NewGUI(20, 4)
+	gui.Begin()
+	gui.ProgressBar(0.5, -3, "")
+	gui.Gauge("Load", 0.5, -1)
+	gui.End()
+}
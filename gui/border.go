@@ -0,0 +1,115 @@
+package imgui
+
+import (
+	"github.com/amecky/table/table"
+)
+
+// BorderSet contains the characters used to draw the border of a cell.
+// Characters which are zero are not drawn
+type BorderSet struct {
+	Horizontal  rune
+	Vertical    rune
+	TopLeft     rune
+	TopRight    rune
+	BottomLeft  rune
+	BottomRight rune
+	// the characters where a separator meets the left and right border
+	LeftT  rune
+	RightT rune
+}
+
+var BORDER_SINGLE = BorderSet{'─', '│', '┌', '┐', '└', '┘', '├', '┤'}
+var BORDER_ROUNDED = BorderSet{'─', '│', '╭', '╮', '╰', '╯', '├', '┤'}
+var BORDER_DOUBLE = BorderSet{'═', '║', '╔', '╗', '╚', '╝', '╠', '╣'}
+var BORDER_THICK = BorderSet{'━', '┃', '┏', '┓', '┗', '┛', '┣', '┫'}
+var BORDER_ASCII = BorderSet{'-', '|', '+', '+', '+', '+', '+', '+'}
+var BORDER_NONE = BorderSet{}
+
+// SetBorder sets the border of all cells which do not define their own.
+// It replaces the border of the theme only for this GUI
+func (g *GUI) SetBorder(set BorderSet) {
+	g.buffer.borders = &set
+	g.buffer.Invalidate()
+}
+
+// border returns the border set of the cell
+func (b *Buffer) border(c cell) BorderSet {
+	if c.opts.Border != nil {
+		return *c.opts.Border
+	}
+	if b.borders != nil {
+		return *b.borders
+	}
+	return b.theme.Borders
+}
+
+func (b *Buffer) setBorder(x, y int, r rune, style int) {
+	if r != 0 {
+		b.Set(x, y, r, style)
+	}
+}
+
+// labelPos returns the x position of a title or footer inside the
+// horizontal border of the cell
+func labelPos(c cell, label string, align table.TextAlign) int {
+	l := internalLen(label)
+	switch align {
+	case table.AlignRight:
+		return max(c.x+1, c.x+c.w-2-l)
+	case table.AlignCenter:
+		return max(c.x+1, c.x+(c.w-1-l)/2)
+	}
+	return c.x + 1
+}
+
+// drawBorder draws the border, title and footer of the cell
+func (b *Buffer) drawBorder(idx int) {
+	c := b.cells[idx]
+	bs := b.border(c)
+	style := ROLE_BORDER
+	if idx == b.focused {
+		style = ROLE_BORDER_FOCUSED
+	}
+	for i := c.x; i < c.x+c.w; i++ {
+		b.setBorder(i, c.y-1, bs.Horizontal, style)
+	}
+	for i := c.x; i < c.x+c.w-1; i++ {
+		b.setBorder(i, c.y+c.h, bs.Horizontal, style)
+	}
+	for i := c.y; i < c.y+c.h; i++ {
+		b.setBorder(c.x-1, i, bs.Vertical, style)
+		b.setBorder(c.x+c.w-1, i, bs.Vertical, style)
+	}
+	b.setBorder(c.x-1, c.y-1, bs.TopLeft, style)
+	b.setBorder(c.x+c.w-1, c.y-1, bs.TopRight, style)
+	b.setBorder(c.x-1, c.y+c.h, bs.BottomLeft, style)
+	b.setBorder(c.x+c.w-1, c.y+c.h, bs.BottomRight, style)
+	if c.title != "" {
+		b.WriteEx(labelPos(c, c.title, c.opts.TitleAlign), c.y-1, c.title, ROLE_HEADER)
+	}
+	if c.opts.Footer != "" {
+		b.WriteEx(labelPos(c, c.opts.Footer, c.opts.FooterAlign), c.y+c.h, c.opts.Footer, style)
+	}
+}
+
+// drawSeparator draws a horizontal line through the cell
+func (b *Buffer) drawSeparator(idx, y int) {
+	c := b.cells[idx]
+	bs := b.border(c)
+	for i := c.x; i < c.x+c.w-1; i++ {
+		b.setBorder(i, y, bs.Horizontal, ROLE_BORDER)
+	}
+	b.setBorder(c.x-1, y, bs.LeftT, ROLE_BORDER)
+	b.setBorder(c.x+c.w-1, y, bs.RightT, ROLE_BORDER)
+}
+
+// focusAt marks the innermost cell at the position as focused
+func (b *Buffer) focusAt(x, y int) {
+	b.focused = -1
+	for i, c := range b.cells {
+		r := rect{x: c.x - 1, y: c.y - 1, w: c.w, h: c.h + 1}
+		if r.Inside(x, y) {
+			b.focused = i
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/amecky/table/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBorderSets(t *testing.T) {
+	gui := NewGUI(30, 10)
+	gui.SetBorder(BORDER_ROUNDED)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("one")
+	gui.EndCell()
+	gui.StartCellEx("", CellOptions{Border: &BORDER_DOUBLE})
+	gui.Text("two")
+	gui.EndCell()
+	gui.StartCellEx("", CellOptions{Border: &BORDER_NONE})
+	gui.Text("three")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	r, _ := gui.buffer.At(0, 0)
+	assert.Equal(t, '╭', r)
+	c := gui.buffer.cells[1]
+	r, _ = gui.buffer.At(c.x-1, c.y+c.h)
+	assert.Equal(t, '╚', r)
+	c = gui.buffer.cells[2]
+	r, _ = gui.buffer.At(c.x-1, c.y)
+	assert.Equal(t, ' ', r)
+}
+
+func TestTitleAndFooter(t *testing.T) {
+	gui := NewGUI(30, 10)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCellEx("Title", CellOptions{Width: 12, TitleAlign: table.AlignRight, Footer: "1/3", FooterAlign: table.AlignCenter})
+	gui.Text("text")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	c := gui.buffer.cells[0]
+	assert.Equal(t, 13, c.w)
+	// the title ends one column before the corner
+	r, _ := gui.buffer.At(c.x+c.w-3, c.y-1)
+	assert.Equal(t, 'e', r)
+	r, _ = gui.buffer.At(c.x+c.w-2, c.y-1)
+	assert.Equal(t, '─', r)
+	r, _ = gui.buffer.At(c.x+4, c.y+c.h)
+	assert.Equal(t, '1', r)
+}
+
+func TestFocusedBorder(t *testing.T) {
+	gui := NewGUI(30, 10)
+	frame := func() {
+		gui.Begin()
+		gui.StartRow()
+		gui.StartCell()
+		gui.Text("one")
+		gui.EndCell()
+		gui.StartCell()
+		gui.Text("two")
+		gui.EndCell()
+		gui.EndRow()
+		gui.End()
+	}
+	frame()
+	assert.Equal(t, -1, gui.buffer.focused)
+	gui.SetMouseEvent(tea.MouseEvent{X: 8, Y: 1, Button: tea.MouseButtonLeft})
+	frame()
+	assert.Equal(t, 1, gui.buffer.focused)
+	_, st := gui.buffer.At(6, 0)
+	assert.Equal(t, FOCUSED_BORDER, st)
+	_, st = gui.buffer.At(0, 0)
+	assert.Equal(t, BORDER, st)
+}
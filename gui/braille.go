@@ -0,0 +1,105 @@
+package imgui
+
+// braille dot bits for a 2x4 cell indexed by [y][x]
+var brailleBits = [4][2]uint8{
+	{0x01, 0x08},
+	{0x02, 0x10},
+	{0x04, 0x20},
+	{0x40, 0x80},
+}
+
+// brailleGrid is a dot matrix with 2x4 dots per terminal cell. Every
+// terminal cell stores the style of the last dot set inside it
+type brailleGrid struct {
+	w      int
+	h      int
+	dots   []uint8
+	styles []int
+}
+
+func newBrailleGrid(w, h int) *brailleGrid {
+	w = max(w, 0)
+	h = max(h, 0)
+	return &brailleGrid{
+		w:      w,
+		h:      h,
+		dots:   make([]uint8, w*h),
+		styles: make([]int, w*h),
+	}
+}
+
+// set turns on the dot at px, py. Coordinates are in dots
+func (bg *brailleGrid) set(px, py, style int) {
+	if px < 0 || py < 0 || px >= bg.w*2 || py >= bg.h*4 {
+		return
+	}
+	idx := (py/4)*bg.w + px/2
+	bg.dots[idx] |= brailleBits[py%4][px%2]
+	bg.styles[idx] = style
+}
+
+// line draws a line between two dots using Bresenham's algorithm
+func (bg *brailleGrid) line(x0, y0, x1, y1, style int) {
+	dx := abs(x1 - x0)
+	dy := -abs(y1 - y0)
+	sx := 1
+	if x0 > x1 {
+		sx = -1
+	}
+	sy := 1
+	if y0 > y1 {
+		sy = -1
+	}
+	e := dx + dy
+	for {
+		bg.set(x0, y0, style)
+		if x0 == x1 && y0 == y1 {
+			return
+		}
+		e2 := 2 * e
+		if e2 >= dy {
+			e += dy
+			x0 += sx
+		}
+		if e2 <= dx {
+			e += dx
+			y0 += sy
+		}
+	}
+}
+
+func (bg *brailleGrid) rune(x, y int) rune {
+	d := bg.dots[y*bg.w+x]
+	if d == 0 {
+		return ' '
+	}
+	return rune(0x2800 + int(d))
+}
+
+// writeRow writes one row of the grid inline
+func (bg *brailleGrid) writeRow(b *Buffer, y int) {
+	runes := make([]rune, bg.w)
+	for x := 0; x < bg.w; x++ {
+		runes[x] = bg.rune(x, y)
+	}
+	writeRuns(b, runes, bg.styles[y*bg.w:(y+1)*bg.w])
+}
+
+// writeRuns writes the runes inline. Consecutive runes with the
+// same style are written as one command
+func writeRuns(b *Buffer, runes []rune, styles []int) {
+	start := 0
+	for i := 1; i <= len(runes); i++ {
+		if i == len(runes) || styles[i] != styles[start] {
+			b.Write(string(runes[start:i]), styles[start], true)
+			start = i
+		}
+	}
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -1,8 +1,11 @@
 package imgui
 
 import (
+	"bytes"
 	"log"
-	"strings"
+
+	"github.com/amecky/table/table"
+	"github.com/rivo/uniseg"
 )
 
 type Vec struct {
@@ -14,31 +17,73 @@ func (v Vec) Matches(x, y int) bool {
 	return x == v.x && y == v.y
 }
 
-type Row struct {
-	cells []int
+// CellOptions defines the size of a cell. Width, Percent and Height
+// are measured between the borders. A cell with Fill set takes the
+// remaining width of the row. Content outside of a cell is clipped
+type CellOptions struct {
+	Width    int
+	Percent  int
+	Height   int
+	MinWidth int
+	Fill     bool
+	// Border overrides the border set of the GUI
+	Border      *BorderSet
+	TitleAlign  table.TextAlign
+	Footer      string
+	FooterAlign table.TextAlign
+	// Ellipsis marks text which is cut at the right side of the cell
+	Ellipsis bool
+	// Background fills the cell with the role or style. The zero value
+	// ROLE_TEXT gives the cell the background of the text and NO_FILL
+	// leaves the cell as it is
+	Background int
 }
 
 type cell struct {
-	title string
+	title  string
+	opts   CellOptions
+	indent int
+	// measured width based on the content
+	mw int
+	// the row containing the cell and all rows nested inside
+	row  int
+	rows []int
 	rect
 }
 
+// sized returns true if the size of the cell does not only depend on the content
+func (c cell) sized() bool {
+	return c.opts.Width > 0 || c.opts.Percent > 0 || c.opts.Height > 0 || c.opts.Fill
+}
+
 type DrawCommand struct {
-	uid     string
-	focus   bool
-	text    string
-	style   int
-	x       int
-	y       int
-	size    int
-	cellIdx int
+	uid       string
+	focus     bool
+	text      string
+	style     int
+	x         int
+	y         int
+	size      int
+	cellIdx   int
+	separator bool
+	aligned   bool
+	align     table.TextAlign
+	// the clip region or -1 for the screen
+	clip int
+	// fill paints the background of size columns
+	fill bool
 }
 
 type Buffer struct {
-	width       int
-	height      int
-	size        int
-	chars       []rune
+	width  int
+	height int
+	size   int
+	chars  []rune
+	// grapheme clusters of more than one rune
+	clusters []string
+	// the style of the fill below every slot
+	fills       []int
+	filled      map[[2]int]int
 	styles      []int
 	currentUID  string
 	uids        *Stack
@@ -47,11 +92,40 @@ type Buffer struct {
 	cells       []cell
 	curX        int
 	curY        int
+	lastX       int
+	lastY       int
 	grouping    bool
 	groupMargin int
 	curCell     int
+	openRows    []int
+	openCells   []int
+	resolved    []int
 	useMenu     bool
 	states      map[string]bool
+	canvases    []*Canvas
+	theme       *Theme
+	// the border set by SetBorder overriding the theme
+	borders *BorderSet
+	sgr     map[int]string
+	sgrBuf  *styleBuffer
+	palette []Style
+	out     bytes.Buffer
+	// the previous frame for differential rendering
+	prevChars    []rune
+	prevStyles   []int
+	prevClusters []string
+	diffGap      int
+	stats        FrameStats
+	// the cell which was clicked last or -1
+	focused int
+	// the clip regions of the frame and the active rectangle while drawing
+	clips        []clipRegion
+	clipStack    []int
+	clipRects    []rect
+	clip         rect
+	clipEllipsis bool
+	drawing      bool
+	children     []int
 }
 
 func NewBuffer(w, h int) *Buffer {
@@ -61,12 +135,22 @@ func NewBuffer(w, h int) *Buffer {
 		height:      h,
 		size:        sz,
 		chars:       make([]rune, sz),
+		clusters:    make([]string, sz),
+		fills:       make([]int, sz),
+		filled:      make(map[[2]int]int),
 		styles:      make([]int, sz),
 		uids:        &Stack{},
 		grouping:    false,
 		groupMargin: 1,
 		states:      make(map[string]bool),
+		theme:       DarkTheme(),
+		focused:     -1,
+		diffGap:     DIFF_GAP,
 	}
+	for i := range ret.fills {
+		ret.fills[i] = NO_FILL
+	}
+	ret.setClip(-1)
 	return ret
 }
 
@@ -74,11 +158,20 @@ func (b *Buffer) Clear() {
 	for i := 0; i < b.size; i++ {
 		b.chars[i] = ' '
 		b.styles[i] = 0
+		b.clusters[i] = ""
+		b.fills[i] = NO_FILL
 	}
 	b.currentUID = ""
 	b.commands = b.commands[:0]
 	b.cells = b.cells[:0]
 	b.rows = b.rows[:0]
+	b.openRows = b.openRows[:0]
+	b.openCells = b.openCells[:0]
+	b.canvases = b.canvases[:0]
+	b.clips = b.clips[:0]
+	b.clipStack = b.clipStack[:0]
+	b.children = b.children[:0]
+	b.setClip(-1)
 	b.curX = 0
 	b.curY = 0
 	b.curCell = 0
@@ -93,8 +186,7 @@ func (b *Buffer) PopID() {
 		b.curX += b.groupMargin
 	} else {
 		//b.curY++
-		c := b.cells[len(b.cells)-1]
-		b.curX = c.x
+		b.curX = b.lineStart()
 	}
 	b.uids.Pop()
 }
@@ -127,6 +219,78 @@ func (b *Buffer) At(x, y int) (rune, int) {
 }
 
 func (b *Buffer) Write(txt string, style int, inline bool) {
+	b.write(txt, internalLen(txt), style, inline)
+}
+
+// Reserve adds an empty area of w x h cells at the current position
+func (b *Buffer) Reserve(w, h int) {
+	for i := 0; i < h; i++ {
+		b.write("", w, 0, false)
+	}
+}
+
+// lineStart returns the x position where a new line starts in the current cell
+func (b *Buffer) lineStart() int {
+	if b.curCell >= len(b.cells) {
+		return 0
+	}
+	c := b.cells[b.curCell]
+	return c.x + c.indent
+}
+
+// SameLine moves the cursor back to the line of the last command. With
+// an offset of zero the cursor is placed one column after the command
+// otherwise offset columns from the start of the line
+func (b *Buffer) SameLine(offset int) {
+	b.curY = b.lastY
+	if offset > 0 {
+		b.curX = b.lineStart() + offset
+	} else {
+		b.curX = b.lastX + 1
+	}
+}
+
+// Indent moves the start of all following lines of the current cell
+func (b *Buffer) Indent(n int) {
+	if b.curCell >= len(b.cells) {
+		return
+	}
+	c := &b.cells[b.curCell]
+	atStart := b.curX == c.x+c.indent
+	c.indent += n
+	if c.indent < 0 {
+		c.indent = 0
+	}
+	if atStart {
+		b.curX = c.x + c.indent
+	}
+}
+
+// Separator adds a horizontal line spanning the whole cell
+func (b *Buffer) Separator() {
+	b.commands = append(b.commands, DrawCommand{
+		uid:       b.uids.Top(),
+		x:         b.lineStart(),
+		y:         b.curY,
+		cellIdx:   b.curCell,
+		separator: true,
+		clip:      b.topClip(),
+	})
+	b.curX = b.lineStart()
+	b.curY++
+}
+
+// SetCursorPos moves the cursor relative to the top left corner of the current cell
+func (b *Buffer) SetCursorPos(x, y int) {
+	if b.curCell >= len(b.cells) {
+		return
+	}
+	c := b.cells[b.curCell]
+	b.curX = c.x + x
+	b.curY = c.y + y
+}
+
+func (b *Buffer) write(txt string, size int, style int, inline bool) {
 	id := b.uids.Top()
 	b.commands = append(b.commands, DrawCommand{
 		uid:     id,
@@ -135,17 +299,21 @@ func (b *Buffer) Write(txt string, style int, inline bool) {
 		focus:   false,
 		x:       b.curX,
 		y:       b.curY,
-		size:    internalLen(txt),
+		size:    size,
 		cellIdx: b.curCell,
+		clip:    b.topClip(),
 	})
+	if size > 0 {
+		b.lastX = b.curX + size
+		b.lastY = b.curY
+	}
 	if inline {
-		b.curX += internalLen(txt)
+		b.curX += size
 	} else {
 		if b.grouping {
-			b.curX += internalLen(txt)
+			b.curX += size
 		} else {
-			cc := b.cells[len(b.cells)-1]
-			b.curX = cc.x
+			b.curX = b.lineStart()
 			b.curY++
 		}
 	}
@@ -162,6 +330,7 @@ func (b *Buffer) WriteEx(x, y int, txt string, style int) {
 		y:       y,
 		size:    internalLen(txt),
 		cellIdx: -1,
+		clip:    b.topClip(),
 	})
 }
 
@@ -203,143 +372,147 @@ func (b *Buffer) Debug() {
 	log.Println("---------------------")
 }
 
+// Set writes the character if it is inside of the active clip rectangle
 func (b *Buffer) Set(x, y int, c rune, style int) {
-	if x < b.width && y < b.height {
-		idx := y*b.width + x
-		if idx < b.size {
-			b.chars[idx] = c
-			b.styles[idx] = style
+	w := internalLen(string(c))
+	if w < 1 {
+		w = 1
+	}
+	b.setCluster(x, y, string(c), w, style)
+}
+
+// drawCommand copies the text into the buffer clipped to the region
+// of the command
+func (b *Buffer) drawCommand(c DrawCommand) {
+	b.setClip(c.clip)
+	if c.y < b.clip.y || c.y >= b.clip.y+b.clip.h {
+		return
+	}
+	right := b.clip.x + b.clip.w
+	x := c.x
+	rest := c.text
+	state := -1
+	for len(rest) > 0 {
+		var cl string
+		var w int
+		cl, rest, w, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		if x+w > right {
+			if b.clipEllipsis && right > max(c.x, b.clip.x) {
+				b.setCluster(right-1, c.y, ELLIPSIS, 1, c.style)
+			}
+			break
 		}
+		b.setCluster(x, c.y, cl, w, c.style)
+		x += w
 	}
 }
 
 func (b *Buffer) String() string {
-	// fill buffer
-	for _, c := range b.cells {
-		for i := c.x; i < c.x+c.w; i++ {
-			b.Set(i, c.y-1, '─', BORDER)
-		}
-		if c.title != "" {
-			b.WriteEx(c.x+1, c.y-1, c.title, HEADER_STYLE)
-			//b.WriteEx(x, c.y-1, " "+c.title+" ", HEADER_STYLE)
-		}
-		for i := c.x; i < c.x+c.w-1; i++ {
-			b.Set(i, c.y+c.h, '─', BORDER)
-		}
-		for i := c.y; i < c.y+c.h; i++ {
-			b.Set(c.x-1, i, '│', BORDER)
-		}
-		for i := c.y; i < c.y+c.h; i++ {
-			b.Set(c.x+c.w-1, i, '│', BORDER)
-		}
-		b.Set(c.x-1, c.y-1, '┌', BORDER)
-		b.Set(c.x+c.w-1, c.y-1, '┐', BORDER)
-		b.Set(c.x-1, c.y+c.h, '└', BORDER)
-		b.Set(c.x+c.w-1, c.y+c.h, '┘', BORDER)
+	b.draw()
+	b.prepareStyles()
+	b.out.Reset()
+	b.out.Grow(b.size * 2)
+	for y := 0; y < b.height-1; y++ {
+		b.writeCells(y*b.width, 0, b.width)
+		b.out.WriteByte('\n')
+	}
+	b.stats = FrameStats{Cells: b.width * (b.height - 1), Spans: b.height - 1, Bytes: b.out.Len(), Full: true}
+	return b.out.String()
+}
 
+// draw fills the grid with the borders and commands of the frame
+func (b *Buffer) draw() {
+	b.resolveClips()
+	b.clipStack = b.clipStack[:0]
+	b.drawing = true
+	defer func() { b.drawing = false }()
+	b.drawFills()
+	for i := range b.cells {
+		b.drawBorder(i)
 	}
 
 	for _, c := range b.commands {
-		if !c.focus {
-			for i, ch := range c.text {
-				b.Set(c.x+i, c.y, ch, c.style)
-			}
+		if c.separator && c.cellIdx < len(b.cells) {
+			b.drawSeparator(c.cellIdx, c.y)
 		}
 	}
 
 	for _, c := range b.commands {
-		if c.focus {
-			for i, ch := range c.text {
-				b.Set(c.x+i, c.y, ch, c.style)
-			}
+		if !c.focus && !c.fill {
+			b.drawCommand(c)
 		}
 	}
-	// convert buffer to string
-	sb := strings.Builder{}
 
-	for y := 0; y < b.height-1; y++ {
-		cy := y * b.width
-		for x := 0; x < b.width; x++ {
-			if b.styles[cy+x] == 0 {
-				sb.WriteString(string(b.chars[cy+x]))
-			} else {
-				st := STYLES[b.styles[cy+x]-1]
-				sb.WriteString(st.Convert(string(b.chars[cy+x])))
-			}
+	for _, c := range b.canvases {
+		b.setClip(c.clip)
+		c.draw(b)
+	}
+
+	for _, c := range b.commands {
+		if c.focus && !c.fill {
+			b.drawCommand(c)
 		}
-		sb.WriteRune('\n')
 	}
-	return sb.String()
+	b.setClip(-1)
 }
 
-func (b *Buffer) StartRow() {
-	b.rows = append(b.rows, Row{})
-
-}
-func (b *Buffer) StartCell() {
-	b.StartCellWithHeader("")
+// prepareStyles resets the cached SGR parameters for a new frame
+func (b *Buffer) prepareStyles() {
+	if b.sgr == nil {
+		b.sgr = make(map[int]string)
+		b.sgrBuf = newBuffer()
+	}
+	clear(b.sgr)
+	b.palette = b.theme.palette()
 }
 
-func (b *Buffer) StartCellWithHeader(title string) {
-	b.cells = append(b.cells, cell{
-		title: title,
-	})
-	if len(b.cells) == 1 {
-		b.curX = 1
-		b.curY = 1
-		if b.useMenu {
-			b.curY++
-		}
-	} else {
-		prev := b.cells[len(b.cells)-2]
-		b.curX = prev.w + 2
-		b.curY = prev.y
-		if len(b.rows) > 1 {
-			b.curX = 1
-			b.curY = b.cells[b.curCell].y + b.cells[b.curCell].h + 2
-		}
-	}
-	cur := &b.cells[len(b.cells)-1]
-	cur.x = b.curX
-	cur.y = b.curY
-	b.curCell = len(b.cells) - 1
-	cr := &b.rows[len(b.rows)-1]
-	cr.cells = append(cr.cells, b.curCell)
-}
-
-func (b *Buffer) EndCell() {
-	if len(b.cells) > 0 {
-		cidx := b.curCell
-		cur := &b.cells[cidx]
-		for _, c := range b.commands {
-			if c.cellIdx == cidx {
-				if c.size+c.x > cur.w {
-					cur.w = c.x + c.size + 1
-				}
-				h := c.y - cur.y
-				if h >= cur.h {
-					cur.h = h + 1
+// writeCells writes the cells from..to of the line starting at cy.
+// Consecutive cells with the same style are written as one run and
+// resets are only written when needed
+func (b *Buffer) writeCells(cy, from, to int) {
+	curID := -1
+	cur := ""
+	for x := from; x < to; x++ {
+		if id := b.styles[cy+x]; id != curID {
+			curID = id
+			p := b.params(id)
+			if p != cur {
+				switch {
+				case p == "":
+					b.out.WriteString(CSI + "0m")
+				case cur == "":
+					b.out.WriteString(CSI + p + "m")
+				default:
+					b.out.WriteString(CSI + "0;" + p + "m")
 				}
+				cur = p
 			}
 		}
-		if cur.x+internalLen(cur.title) > cur.w {
-			cur.w = cur.x + internalLen(cur.title)
+		switch {
+		case b.clusters[cy+x] != "":
+			b.out.WriteString(b.clusters[cy+x])
+		case b.chars[cy+x] != WIDE_CONTINUATION:
+			b.out.WriteRune(b.chars[cy+x])
 		}
-
+	}
+	if cur != "" {
+		b.out.WriteString(CSI + "0m")
 	}
 }
 
-func (b *Buffer) EndRow() {
-	cr := &b.rows[len(b.rows)-1]
-	my := 0
-	for _, c := range cr.cells {
-		cell := b.cells[c]
-		if cell.h > my {
-			my = cell.h
-		}
+// params returns the SGR parameters of a style id. They are cached
+// during one frame
+func (b *Buffer) params(id int) string {
+	if p, ok := b.sgr[id]; ok {
+		return p
 	}
-	for _, c := range cr.cells {
-		cell := &b.cells[c]
-		cell.h = my
+	st := b.palette[0]
+	if id >= ROLE_COUNT {
+		st = customStyle(id)
+	} else if id > 0 {
+		st = b.palette[id]
 	}
+	p := st.params(b.sgrBuf)
+	b.sgr[id] = p
+	return p
 }
@@ -2,6 +2,7 @@ package imgui
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/alecthomas/assert"
@@ -23,3 +24,46 @@ func TestCells(t *testing.T) {
 	fmt.Println(b)
 	assert.Equal(t, 2, len(b.cells))
 }
+
+func TestCellOptions(t *testing.T) {
+	gui := NewGUI(40, 8)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("Hello")
+	gui.EndCell()
+	gui.StartCellEx("", CellOptions{Fill: true})
+	gui.Text("World")
+	gui.EndCell()
+	gui.StartCellEx("", CellOptions{Width: 4})
+	gui.Text("Clipped text")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+	fill := gui.buffer.cells[1]
+	fixed := gui.buffer.cells[2]
+	assert.Equal(t, 5, fixed.w)
+	assert.Equal(t, 40, fixed.x+fixed.w)
+	assert.Equal(t, fixed.x-1, fill.x+fill.w)
+	r, _ := gui.buffer.At(fixed.x+3, 1)
+	assert.Equal(t, 'p', r)
+	r, _ = gui.buffer.At(fixed.x+4, 1)
+	assert.Equal(t, '│', r)
+}
+
+func TestStyleRuns(t *testing.T) {
+	defer SetColorProfile(CurrentColorProfile())
+	SetColorProfile(PROFILE_ANSI16)
+	b := NewBuffer(8, 3)
+	b.Clear()
+	red := AddStyle(NewStyle("#ff0000", "", false))
+	blue := AddStyle(NewStyle("#0000ff", "", false))
+	for x := 0; x < 3; x++ {
+		b.Set(x, 0, 'r', red)
+		b.Set(x+3, 0, 'b', blue)
+	}
+	b.Set(0, 1, 'x', red)
+	lines := strings.Split(b.String(), "\n")
+	assert.Equal(t, "\x1b[91mrrr\x1b[0;94mbbb\x1b[0m  ", lines[0])
+	assert.Equal(t, "\x1b[91mx\x1b[0m       ", lines[1])
+}
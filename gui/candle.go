@@ -0,0 +1,190 @@
+package imgui
+
+import (
+	"fmt"
+	"math"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type Candle struct {
+	Time  string
+	Open  float64
+	High  float64
+	Low   float64
+	Close float64
+}
+
+// candleState keeps the scroll offset (number of candles hidden on the
+// right side) and the zoom (number of columns per candle) of a chart
+type candleState struct {
+	offset int
+	zoom   int
+}
+
+const MAX_CANDLE_ZOOM = 4
+
+// candleRune returns the box drawing character for a cell where the upper
+// and lower half can either be empty (0), wick (1) or body (2)
+func candleRune(top, bottom int) rune {
+	switch {
+	case top == 2 && bottom == 2:
+		return '┃'
+	case top == 2 && bottom == 1:
+		return '╿'
+	case top == 1 && bottom == 2:
+		return '╽'
+	case top == 2:
+		return '╹'
+	case bottom == 2:
+		return '╻'
+	case top == 1 && bottom == 1:
+		return '│'
+	case top == 1:
+		return '╵'
+	case bottom == 1:
+		return '╷'
+	}
+	return ' '
+}
+
+func (g *GUI) candleState(id string) *candleState {
+	st, ok := g.candles[id]
+	if !ok {
+		st = &candleState{zoom: 2}
+		g.candles[id] = st
+	}
+	return st
+}
+
+// handleCandleWheel scrolls or zooms the chart when the wheel is used
+// inside the area. Holding ctrl while using the wheel zooms
+func (g *GUI) handleCandleWheel(st *candleState, area rect) {
+	if g.processed != 1 || !area.Inside(g.mouseEvent.X, g.mouseEvent.Y) {
+		return
+	}
+	e := g.mouseEvent
+	switch {
+	case e.Ctrl && e.Button == tea.MouseButtonWheelUp:
+		st.zoom++
+	case e.Ctrl && e.Button == tea.MouseButtonWheelDown:
+		st.zoom--
+	case e.Button == tea.MouseButtonWheelUp || e.Button == tea.MouseButtonWheelLeft:
+		st.offset++
+	case e.Button == tea.MouseButtonWheelDown || e.Button == tea.MouseButtonWheelRight:
+		st.offset--
+	default:
+		return
+	}
+	g.processed = -1
+	if st.zoom < 1 {
+		st.zoom = 1
+	}
+	if st.zoom > MAX_CANDLE_ZOOM {
+		st.zoom = MAX_CANDLE_ZOOM
+	}
+}
+
+// CandleChart draws the candles inside an area of width x height cells.
+// The Y axis is scaled to the visible candles. The mouse wheel scrolls
+// the chart and ctrl plus mouse wheel zooms. Below the chart the OHLC
+// values of the hovered or the last visible candle are shown
+func (g *GUI) CandleChart(label string, candles []Candle, width, height int) {
+	g.buffer.PushID("CANDLES_" + label)
+	st := g.candleState(g.buffer.uids.Path())
+	if width < 1 {
+		width = 1
+	}
+	if height < 2 {
+		height = 2
+	}
+	if label != "" {
+		g.buffer.Write(label, ROLE_HEADER, false)
+	}
+	pos := g.buffer.CurrentPos()
+	g.handleCandleWheel(st, rect{x: pos.x, y: pos.y, w: width - 1, h: height - 1})
+	visible := width / st.zoom
+	if st.offset > len(candles)-visible {
+		st.offset = len(candles) - visible
+	}
+	if st.offset < 0 {
+		st.offset = 0
+	}
+	end := len(candles) - st.offset
+	start := end - visible
+	if start < 0 {
+		start = 0
+	}
+	shown := candles[start:end]
+	mn := math.Inf(1)
+	mx := math.Inf(-1)
+	for _, c := range shown {
+		mn = math.Min(mn, c.Low)
+		mx = math.Max(mx, c.High)
+	}
+	if len(shown) == 0 {
+		mn, mx = 0, 1
+	}
+	if mn == mx {
+		mn--
+		mx++
+	}
+	// every cell is split in an upper and lower half
+	sub := func(v float64) int {
+		return int(math.Round((mx - v) / (mx - mn) * float64(height*2-1)))
+	}
+	runes := make([]rune, width*height)
+	styles := make([]int, width*height)
+	for i := range runes {
+		runes[i] = ' '
+	}
+	for i, c := range shown {
+		x := i * st.zoom
+		style := ROLE_SUCCESS_LIGHT
+		if c.Close < c.Open {
+			style = ROLE_DANGER
+		}
+		hi := sub(c.High)
+		lo := sub(c.Low)
+		bt := sub(math.Max(c.Open, c.Close))
+		bb := sub(math.Min(c.Open, c.Close))
+		kind := func(s int) int {
+			if s >= bt && s <= bb {
+				return 2
+			}
+			if s >= hi && s <= lo {
+				return 1
+			}
+			return 0
+		}
+		for y := 0; y < height; y++ {
+			idx := y*width + x
+			runes[idx] = candleRune(kind(y*2), kind(y*2+1))
+			styles[idx] = style
+		}
+	}
+	ticks := map[int]float64{
+		0:          mx,
+		height / 2: mx - (mx-mn)*float64(height/2)/float64(height-1),
+		height - 1: mn,
+	}
+	for y := 0; y < height; y++ {
+		writeRuns(g.buffer, runes[y*width:(y+1)*width], styles[y*width:(y+1)*width])
+		if v, ok := ticks[y]; ok {
+			g.buffer.Write(fmt.Sprintf("┤ %.2f", v), ROLE_BORDER, false)
+		} else {
+			g.buffer.Write("│", ROLE_BORDER, false)
+		}
+	}
+	hovered := len(shown) - 1
+	if g.mouseY >= pos.y && g.mouseY < pos.y+height && g.mouseX >= pos.x && g.mouseX < pos.x+len(shown)*st.zoom {
+		hovered = (g.mouseX - pos.x) / st.zoom
+	}
+	if hovered >= 0 {
+		c := shown[hovered]
+		g.buffer.Write(fmt.Sprintf("%s O %.2f H %.2f L %.2f C %.2f", c.Time, c.Open, c.High, c.Low, c.Close), 0, false)
+	} else {
+		g.buffer.Write(" ", 0, false)
+	}
+	g.buffer.PopID()
+}
@@ -0,0 +1,51 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCandleRune(t *testing.T) {
+	assert.Equal(t, '┃', candleRune(2, 2))
+	assert.Equal(t, '╽', candleRune(1, 2))
+	assert.Equal(t, '╵', candleRune(1, 0))
+	assert.Equal(t, ' ', candleRune(0, 0))
+}
+
+func TestCandleChartScroll(t *testing.T) {
+	candles := make([]Candle, 20)
+	for i := range candles {
+		candles[i] = Candle{Open: 1, High: 3, Low: 0, Close: 2}
+	}
+	gui := NewGUI(40, 10)
+	gui.Begin()
+	gui.SetMouseEvent(tea.MouseEvent{X: 2, Y: 2, Button: tea.MouseButtonWheelUp})
+	gui.CandleChart("", candles, 10, 4)
+	gui.End()
+	assert.Equal(t, 1, gui.candles["CANDLES_"].offset)
+
+	gui.Begin()
+	gui.SetMouseEvent(tea.MouseEvent{X: 2, Y: 2, Button: tea.MouseButtonWheelUp, Ctrl: true})
+	gui.CandleChart("", candles, 10, 4)
+	gui.End()
+	assert.Equal(t, 3, gui.candles["CANDLES_"].zoom)
+}
+
+func TestCandleChartState(t *testing.T) {
+	candles := make([]Candle, 20)
+	gui := NewGUI(40, 20)
+	gui.Begin()
+	gui.SetMouseEvent(tea.MouseEvent{X: 2, Y: 2, Button: tea.MouseButtonWheelUp})
+	gui.PushID("first")
+	gui.CandleChart("", candles, 10, 4)
+	gui.PopID()
+	gui.PushID("second")
+	gui.CandleChart("", candles, 10, 4)
+	gui.PopID()
+	gui.End()
+	// unlabeled charts in different ID scopes keep their own state
+	assert.Equal(t, 1, gui.candles["first/CANDLES_"].offset)
+	assert.Equal(t, 0, gui.candles["second/CANDLES_"].offset)
+}
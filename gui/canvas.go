@@ -0,0 +1,155 @@
+package imgui
+
+import "math"
+
+// Point is a position on a canvas in dots
+type Point struct {
+	X int
+	Y int
+}
+
+// Canvas is a drawing surface with 2x4 braille dots per cell. All
+// coordinates are in dots starting at the top left corner. The canvas
+// is drawn into the buffer when the frame is rendered so it can be
+// used until End is called
+type Canvas struct {
+	grid  *brailleGrid
+	x     int
+	y     int
+	cell  int
+	clip  int
+	style int
+}
+
+// Canvas reserves an area of w x h cells at the current position
+// and returns the drawing surface
+func (g *GUI) Canvas(w, h int) *Canvas {
+	w = max(w, 0)
+	h = max(h, 0)
+	g.buffer.PushID("CANVAS")
+	pos := g.buffer.CurrentPos()
+	c := &Canvas{
+		grid: newBrailleGrid(w, h),
+		x:    pos.x,
+		y:    pos.y,
+		cell: g.buffer.curCell,
+		clip: g.buffer.topClip(),
+	}
+	g.buffer.Reserve(w, h)
+	g.buffer.canvases = append(g.buffer.canvases, c)
+	g.buffer.PopID()
+	return c
+}
+
+// Width returns the width in dots
+func (c *Canvas) Width() int {
+	return c.grid.w * 2
+}
+
+// Height returns the height in dots
+func (c *Canvas) Height() int {
+	return c.grid.h * 4
+}
+
+// SetColor sets the color used by all following primitives. Every cell
+// takes the color of the last dot drawn inside it
+func (c *Canvas) SetColor(hex string) *Canvas {
+	c.style = AddStyle(NewStyle(hex, "", false))
+	return c
+}
+
+// SetStyle sets the style used by all following primitives
+func (c *Canvas) SetStyle(style int) *Canvas {
+	c.style = style
+	return c
+}
+
+func (c *Canvas) Point(x, y int) *Canvas {
+	c.grid.set(x, y, c.style)
+	return c
+}
+
+func (c *Canvas) Line(x0, y0, x1, y1 int) *Canvas {
+	c.grid.line(x0, y0, x1, y1, c.style)
+	return c
+}
+
+// Polyline connects all points with lines
+func (c *Canvas) Polyline(points ...Point) *Canvas {
+	if len(points) == 1 {
+		c.Point(points[0].X, points[0].Y)
+	}
+	for i := 1; i < len(points); i++ {
+		c.Line(points[i-1].X, points[i-1].Y, points[i].X, points[i].Y)
+	}
+	return c
+}
+
+// Rect draws the outline of a rectangle
+func (c *Canvas) Rect(x, y, w, h int) *Canvas {
+	if w <= 0 || h <= 0 {
+		return c
+	}
+	return c.Polyline(Point{x, y}, Point{x + w - 1, y}, Point{x + w - 1, y + h - 1}, Point{x, y + h - 1}, Point{x, y})
+}
+
+// Fill draws a filled rectangle
+func (c *Canvas) Fill(x, y, w, h int) *Canvas {
+	for j := y; j < y+h; j++ {
+		for i := x; i < x+w; i++ {
+			c.grid.set(i, j, c.style)
+		}
+	}
+	return c
+}
+
+// Circle draws the outline of a circle using the midpoint algorithm
+func (c *Canvas) Circle(cx, cy, r int) *Canvas {
+	x := r
+	y := 0
+	e := 1 - r
+	for x >= y {
+		for _, p := range [][2]int{{x, y}, {y, x}, {-y, x}, {-x, y}, {-x, -y}, {-y, -x}, {y, -x}, {x, -y}} {
+			c.grid.set(cx+p[0], cy+p[1], c.style)
+		}
+		y++
+		if e < 0 {
+			e += 2*y + 1
+		} else {
+			x--
+			e += 2*(y-x) + 1
+		}
+	}
+	return c
+}
+
+// FillCircle draws a filled circle
+func (c *Canvas) FillCircle(cx, cy, r int) *Canvas {
+	for y := -r; y <= r; y++ {
+		dx := int(math.Sqrt(float64(r*r - y*y)))
+		for x := -dx; x <= dx; x++ {
+			c.grid.set(cx+x, cy+y, c.style)
+		}
+	}
+	return c
+}
+
+// Clear removes all dots
+func (c *Canvas) Clear() *Canvas {
+	for i := range c.grid.dots {
+		c.grid.dots[i] = 0
+		c.grid.styles[i] = 0
+	}
+	return c
+}
+
+// draw copies all cells containing dots into the buffer
+func (c *Canvas) draw(b *Buffer) {
+	for y := 0; y < c.grid.h; y++ {
+		for x := 0; x < c.grid.w; x++ {
+			if c.grid.dots[y*c.grid.w+x] != 0 {
+				b.Set(c.x+x, c.y+y, c.grid.rune(x, y), c.grid.styles[y*c.grid.w+x])
+			}
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestCanvas(t *testing.T) {
+	gui := NewGUI(20, 6)
+	gui.Begin()
+	c := gui.Canvas(2, 1)
+	assert.Equal(t, 4, c.Width())
+	assert.Equal(t, 4, c.Height())
+	c.Fill(0, 0, 2, 4).SetStyle(ARROW_STYLE).Point(3, 3)
+	gui.End()
+	r, s := gui.buffer.At(1, 1)
+	assert.Equal(t, '⣿', r)
+	assert.Equal(t, 0, s)
+	r, s = gui.buffer.At(2, 1)
+	assert.Equal(t, '⢀', r)
+	assert.Equal(t, ARROW_STYLE, s)
+}
+
+func TestCanvasNegativeSize(t *testing.T) {
+	gui := NewGUI(20, 6)
+	gui.Begin()
+	c := gui.Canvas(-2, -1)
+	assert.Equal(t, 0, c.Width())
+	c.Line(0, 0, 5, 5)
+	gui.End()
+}
+
+func TestCanvasCircle(t *testing.T) {
+	bg := newBrailleGrid(3, 2)
+	c := &Canvas{grid: bg}
+	c.Circle(2, 2, 2)
+	isSet := func(x, y int) bool {
+		return bg.dots[(y/4)*bg.w+x/2]&brailleBits[y%4][x%2] != 0
+	}
+	assert.False(t, isSet(2, 2))
+	assert.True(t, isSet(0, 2))
+	assert.True(t, isSet(4, 2))
+	assert.True(t, isSet(2, 0))
+	assert.True(t, isSet(2, 4))
+}
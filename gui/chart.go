@@ -0,0 +1,151 @@
+package imgui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/amecky/table/table"
+)
+
+var SPARK_BLOCKS = []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// SERIES_ROLES are the roles of the theme which give the colors of
+// series and bars without an own color
+var SERIES_ROLES = []int{ROLE_ACCENT, ROLE_SUCCESS_LIGHT, ROLE_DANGER, ROLE_WARNING, ROLE_INFO, ROLE_HEADER}
+
+// Series is one line inside a LineChart. If Color is empty the
+// color is taken from the foreground of SERIES_ROLES
+type Series struct {
+	Name   string
+	Values []float64
+	Color  string
+}
+
+// minMax returns the range of the finite values
+func minMax(values []float64) (float64, float64) {
+	return valueRange([][]float64{values})
+}
+
+// sparkline converts the last width values into block characters.
+// Values which are not finite are left empty
+func sparkline(values []float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	mn, mx := minMax(values)
+	ret := make([]rune, 0, len(values))
+	for _, v := range values {
+		if !finite(v) {
+			ret = append(ret, ' ')
+			continue
+		}
+		idx := int(normalize(v, mn, mx) * float64(len(SPARK_BLOCKS)-1))
+		ret = append(ret, SPARK_BLOCKS[idx])
+	}
+	return string(ret)
+}
+
+// Sparkline draws the last width values as a single line of block characters
+func (g *GUI) Sparkline(values []float64, width int) {
+	g.buffer.PushID("SPARKLINE")
+	g.buffer.Write(sparkline(values, width), ROLE_HIGHLIGHT, false)
+	g.buffer.PopID()
+}
+
+// seriesStyle returns the style of the color or of the series role at idx
+func seriesStyle(t *Theme, color string, idx int) int {
+	if color != "" {
+		return AddStyle(NewStyle(color, "", false))
+	}
+	st := t.Style(SERIES_ROLES[idx%len(SERIES_ROLES)])
+	return AddStyle(Style{foreground: st.foreground, flags: st.flags & ATTR_FOREGROUND})
+}
+
+// LineChart plots all series on a braille canvas of width x height cells
+// including a Y axis with tick labels, a X axis and a legend
+func (g *GUI) LineChart(label string, series []Series, width, height int) {
+	g.buffer.PushID("LINECHART_" + label)
+	if width < 2 {
+		width = 2
+	}
+	if height < 2 {
+		height = 2
+	}
+	all := make([][]float64, 0, len(series))
+	n := 0
+	for _, s := range series {
+		all = append(all, s.Values)
+		if len(s.Values) > n {
+			n = len(s.Values)
+		}
+	}
+	mn, mx := valueRange(all)
+	if mn == mx {
+		mn--
+		mx++
+	}
+	grid := newBrailleGrid(width, height)
+	dw := width*2 - 1
+	dh := height*4 - 1
+	for i, s := range series {
+		st := seriesStyle(g.buffer.theme, s.Color, i)
+		px, py := -1, -1
+		for j, v := range s.Values {
+			if !finite(v) {
+				px = -1
+				continue
+			}
+			x := 0
+			if n > 1 {
+				x = j * dw / (n - 1)
+			}
+			y := dh - int(math.Round(normalize(v, mn, mx)*float64(dh)))
+			if px == -1 {
+				grid.set(x, y, st)
+			} else {
+				grid.line(px, py, x, y, st)
+			}
+			px, py = x, y
+		}
+	}
+	ticks := map[int]string{
+		0:          fmt.Sprintf("%.2f", mx),
+		height / 2: fmt.Sprintf("%.2f", mn+(mx-mn)*float64(height-1-height/2)/float64(height-1)),
+		height - 1: fmt.Sprintf("%.2f", mn),
+	}
+	lw := 0
+	for _, t := range ticks {
+		if internalLen(t) > lw {
+			lw = internalLen(t)
+		}
+	}
+	if label != "" {
+		g.buffer.Write(label, ROLE_HEADER, false)
+	}
+	for y := 0; y < height; y++ {
+		if t, ok := ticks[y]; ok {
+			g.buffer.Write(formatString(t, lw, table.AlignRight)+" ┤", ROLE_BORDER, true)
+		} else {
+			g.buffer.Write(strings.Repeat(" ", lw)+" │", ROLE_BORDER, true)
+		}
+		grid.writeRow(g.buffer, y)
+		g.buffer.Write("", 0, false)
+	}
+	g.buffer.Write(strings.Repeat(" ", lw+1)+"└"+strings.Repeat("─", width), ROLE_BORDER, false)
+	last := 0
+	if n > 0 {
+		last = n - 1
+	}
+	g.buffer.Write(strings.Repeat(" ", lw+2)+formatString("0", width/2, table.AlignLeft)+formatString(fmt.Sprintf("%d", last), width-width/2, table.AlignRight), 0, false)
+	g.buffer.Write(strings.Repeat(" ", lw+2), 0, true)
+	for i, s := range series {
+		g.buffer.Write("■", seriesStyle(g.buffer.theme, s.Color, i), true)
+		g.buffer.Write(" "+s.Name+"  ", 0, true)
+	}
+	g.buffer.Write("", 0, false)
+	g.buffer.PopID()
+}
@@ -0,0 +1,47 @@
+package imgui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄█", sparkline([]float64{1, 2, 3}, 10))
+	assert.Equal(t, "▁█", sparkline([]float64{5, 1, 2}, 2))
+}
+
+func TestSparklineNonFinite(t *testing.T) {
+	assert.Equal(t, "▁ █ ", sparkline([]float64{1, math.Inf(1), 3, math.NaN()}, 10))
+	assert.Equal(t, "", sparkline([]float64{1, 2, 3}, -1))
+	assert.Equal(t, "", sparkline([]float64{1, 2, 3}, 0))
+}
+
+func TestMinMaxNonFinite(t *testing.T) {
+	mn, mx := minMax([]float64{math.Inf(-1), 2, math.NaN(), 5, math.Inf(1)})
+	assert.Equal(t, 2.0, mn)
+	assert.Equal(t, 5.0, mx)
+}
+
+func TestLineChartNonFinite(t *testing.T) {
+	gui := NewGUI(40, 12)
+	gui.Begin()
+	gui.LineChart("", []Series{{Name: "a", Values: []float64{1, math.Inf(1), 3, math.Inf(-1), 2}}}, 10, 4)
+	gui.End()
+	b := gui.buffer
+	found := false
+	for i := range b.chars {
+		if b.chars[i] >= 0x2800 && b.chars[i] <= 0x28ff && b.chars[i] != 0x2800 {
+			found = true
+		}
+	}
+	assert.True(t, found)
+}
+
+func TestBrailleLine(t *testing.T) {
+	bg := newBrailleGrid(2, 1)
+	bg.line(0, 0, 3, 3, 1)
+	assert.Equal(t, '⠑', bg.rune(0, 0))
+	assert.Equal(t, '⢄', bg.rune(1, 0))
+}
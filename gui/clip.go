@@ -0,0 +1,218 @@
+package imgui
+
+// The clip stack limits where commands are drawn. Every cell pushes a
+// region covering its content and all commands remember the region on
+// top of the stack when they are recorded. The rectangle of a cell is
+// only known after the layout so the regions are resolved when the frame
+// is drawn. A region is always intersected with its parent unless it is
+// a popup which can be drawn on top of everything else. Cells, children,
+// forms, menus and split dividers push their regions automatically.
+//
+// Set writes into the grid right away. While the frame is recorded it
+// honours the fixed rectangles on the stack because the cells are not
+// sized yet.
+
+type clipRegion struct {
+	// the cell the region belongs to or -1 for a fixed rectangle
+	cell int
+	// the cell a fixed rectangle moves with or -1 for a position on the screen
+	anchor int
+	parent int
+	// mark cut text with an ellipsis
+	ellipsis bool
+	// the content of a child does not change the size of the cell
+	child bool
+	rect
+}
+
+// PushClip limits all following commands to the rectangle
+func (b *Buffer) PushClip(x, y, w, h int) {
+	b.pushClip(clipRegion{cell: -1, anchor: -1, parent: b.topClip(), rect: rect{x: x, y: y, w: w, h: h}})
+}
+
+// PushClipEllipsis works like PushClip and marks cut text with an ellipsis
+func (b *Buffer) PushClipEllipsis(x, y, w, h int) {
+	b.pushClip(clipRegion{cell: -1, anchor: -1, parent: b.topClip(), ellipsis: true, rect: rect{x: x, y: y, w: w, h: h}})
+}
+
+// PushPopup starts a region which is not clipped by the enclosing cells
+func (b *Buffer) PushPopup(x, y, w, h int) {
+	b.pushClip(clipRegion{cell: -1, anchor: -1, parent: -1, rect: rect{x: x, y: y, w: w, h: h}})
+}
+
+func (b *Buffer) PopClip() {
+	if len(b.clipStack) > 0 {
+		b.clipStack = b.clipStack[:len(b.clipStack)-1]
+		b.setClip(b.topClip())
+	}
+}
+
+// pushClip adds the region and returns its index
+func (b *Buffer) pushClip(r clipRegion) int {
+	b.clips = append(b.clips, r)
+	b.clipStack = append(b.clipStack, len(b.clips)-1)
+	b.setClip(b.topClip())
+	return len(b.clips) - 1
+}
+
+// pushAnchoredClip starts a region at x, y which moves together with
+// the current cell
+func (b *Buffer) pushAnchoredClip(x, y, w, h int, child bool) int {
+	r := clipRegion{cell: -1, anchor: -1, parent: b.topClip(), child: child, rect: rect{x: x, y: y, w: w, h: h}}
+	if b.curCell < len(b.cells) {
+		r.anchor = b.curCell
+		r.x -= b.cells[b.curCell].x
+	}
+	return b.pushClip(r)
+}
+
+// popClipTo removes the region and everything pushed after it
+func (b *Buffer) popClipTo(idx int) {
+	for i := len(b.clipStack) - 1; i >= 0; i-- {
+		if b.clipStack[i] == idx {
+			b.clipStack = b.clipStack[:i]
+			b.setClip(b.topClip())
+			return
+		}
+	}
+}
+
+// pushCellClip starts the region of the content of a cell
+func (b *Buffer) pushCellClip(idx int) {
+	b.pushClip(clipRegion{cell: idx, anchor: -1, parent: b.topClip(), ellipsis: b.cells[idx].opts.Ellipsis})
+}
+
+// popCellClip removes the region of the cell and everything
+// pushed inside of the cell and not removed
+func (b *Buffer) popCellClip(idx int) {
+	for i := len(b.clipStack) - 1; i >= 0; i-- {
+		if b.clips[b.clipStack[i]].cell == idx {
+			b.popClipTo(b.clipStack[i])
+			return
+		}
+	}
+}
+
+// inChild returns true if the region is part of a child
+func (b *Buffer) inChild(idx int) bool {
+	for ; idx >= 0; idx = b.clips[idx].parent {
+		if b.clips[idx].child {
+			return true
+		}
+	}
+	return false
+}
+
+// topClip returns the region on top of the stack or -1 for the screen
+func (b *Buffer) topClip() int {
+	if len(b.clipStack) == 0 {
+		return -1
+	}
+	return b.clipStack[len(b.clipStack)-1]
+}
+
+// intersect returns the area covered by both rectangles
+func (r rect) intersect(o rect) rect {
+	x := max(r.x, o.x)
+	y := max(r.y, o.y)
+	w := min(r.x+r.w, o.x+o.w) - x
+	h := min(r.y+r.h, o.y+o.h) - y
+	return rect{x: x, y: y, w: max(w, 0), h: max(h, 0)}
+}
+
+// resolveClips calculates the rectangles of all regions after the
+// layout. Parents are always pushed before their children
+func (b *Buffer) resolveClips() {
+	screen := rect{x: 0, y: 0, w: b.width, h: b.height}
+	b.clipRects = b.clipRects[:0]
+	for _, r := range b.clips {
+		cr := b.fixedRect(r)
+		if r.cell >= 0 && r.cell < len(b.cells) {
+			cr = b.cells[r.cell].rect
+			// the right border is not part of the content
+			cr.w--
+		}
+		if r.parent >= 0 {
+			cr = cr.intersect(b.clipRects[r.parent])
+		}
+		b.clipRects = append(b.clipRects, cr.intersect(screen))
+	}
+}
+
+// fixedRect returns the rectangle of a fixed region on the screen
+func (b *Buffer) fixedRect(r clipRegion) rect {
+	if r.anchor >= 0 && r.anchor < len(b.cells) {
+		r.x += b.cells[r.anchor].x
+	}
+	return r.rect
+}
+
+// setClip activates the region for the following calls of Set. While
+// drawing the resolved rectangles are used otherwise only the fixed
+// rectangles of the region and its parents
+func (b *Buffer) setClip(idx int) {
+	b.clip = rect{x: 0, y: 0, w: b.width, h: b.height}
+	b.clipEllipsis = false
+	if idx < 0 || idx >= len(b.clips) {
+		return
+	}
+	b.clipEllipsis = b.clips[idx].ellipsis
+	if b.drawing {
+		b.clip = b.clipRects[idx]
+		return
+	}
+	for i := idx; i >= 0; i = b.clips[i].parent {
+		if r := b.clips[i]; r.cell == -1 {
+			b.clip = b.clip.intersect(b.fixedRect(r))
+		}
+	}
+}
+
+// BeginChild starts an area of w x h at the cursor. Everything
+// outside of the area is cut and the cell only grows by its size
+func (b *Buffer) BeginChild(w, h int) {
+	b.children = append(b.children, b.pushAnchoredClip(b.curX, b.curY, max(w, 0), max(h, 0), true))
+}
+
+// EndChild continues below the child area
+func (b *Buffer) EndChild() {
+	if len(b.children) == 0 {
+		return
+	}
+	idx := b.children[len(b.children)-1]
+	b.children = b.children[:len(b.children)-1]
+	b.popClipTo(idx)
+	r := b.fixedRect(b.clips[idx])
+	b.curX = r.x
+	b.curY = r.y
+	b.Reserve(r.w, r.h)
+}
+
+// clipped returns true if a character of width w at x, y is outside of the active region
+func (b *Buffer) clipped(x, y, w int) bool {
+	c := b.clip
+	return x < c.x || y < c.y || x+w > c.x+c.w || y >= c.y+c.h
+}
+
+// PushClip limits all following widgets to the rectangle
+func (g *GUI) PushClip(x, y, w, h int) {
+	g.buffer.PushClip(x, y, w, h)
+}
+
+// PushPopup lets the following widgets draw outside of the current cell
+func (g *GUI) PushPopup(x, y, w, h int) {
+	g.buffer.PushPopup(x, y, w, h)
+}
+
+func (g *GUI) PopClip() {
+	g.buffer.PopClip()
+}
+
+func (g *GUI) BeginChild(w, h int) {
+	g.buffer.BeginChild(w, h)
+	g.pushScope(SCOPE_CHILD)
+}
+
+func (g *GUI) EndChild() {
+	g.popScope(SCOPE_CHILD)
+}
@@ -0,0 +1,127 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestClipCell(t *testing.T) {
+	gui := NewGUI(30, 6)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCellEx("", CellOptions{Width: 5})
+	gui.Text("Hello World")
+	gui.EndCell()
+	gui.StartCellEx("", CellOptions{Width: 5, Ellipsis: true})
+	gui.Text("Hello World")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	b := gui.buffer
+	c := b.cells[0]
+	assert.Equal(t, "o", b.Cell(c.x+4, c.y))
+	assert.Equal(t, "│", b.Cell(c.x+c.w-1, c.y))
+	c = b.cells[1]
+	assert.Equal(t, "l", b.Cell(c.x+3, c.y))
+	assert.Equal(t, ELLIPSIS, b.Cell(c.x+4, c.y))
+	assert.Equal(t, "│", b.Cell(c.x+c.w-1, c.y))
+}
+
+func TestClipStack(t *testing.T) {
+	gui := NewGUI(30, 6)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCellEx("", CellOptions{Width: 10})
+	b := gui.buffer
+	c := b.cells[len(b.cells)-1]
+	b.PushClip(0, 0, c.x+2, 6)
+	gui.Text("abcdef")
+	b.PopClip()
+	b.PushPopup(0, 0, 30, 6)
+	b.WriteEx(c.x+8, c.y+1, "popup", 0)
+	b.PopClip()
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	c = b.cells[len(b.cells)-1]
+	assert.Equal(t, "b", b.Cell(c.x+1, c.y))
+	assert.Equal(t, " ", b.Cell(c.x+2, c.y))
+	// a popup is not clipped by the cell
+	assert.Equal(t, "p", b.Cell(c.x+c.w-1, c.y+1))
+}
+
+func TestClipSet(t *testing.T) {
+	b := NewBuffer(10, 3)
+	b.Clear()
+	b.clipRects = append(b.clipRects[:0], rect{x: 2, y: 1, w: 3, h: 1})
+	b.clips = append(b.clips[:0], clipRegion{cell: -1, anchor: -1, parent: -1})
+	b.drawing = true
+	b.setClip(0)
+	for x := 0; x < 10; x++ {
+		b.Set(x, 1, 'x', 0)
+		b.Set(x, 0, 'x', 0)
+	}
+	assert.Equal(t, " ", b.Cell(1, 1))
+	assert.Equal(t, "x", b.Cell(2, 1))
+	assert.Equal(t, "x", b.Cell(4, 1))
+	assert.Equal(t, " ", b.Cell(5, 1))
+	assert.Equal(t, " ", b.Cell(3, 0))
+}
+
+func TestClipDirectSet(t *testing.T) {
+	b := NewBuffer(10, 3)
+	b.Clear()
+	b.PushClip(2, 1, 3, 1)
+	for x := 0; x < 10; x++ {
+		b.Set(x, 1, 'x', 0)
+	}
+	b.PopClip()
+	b.Set(0, 0, 'y', 0)
+	assert.Equal(t, " ", b.Cell(1, 1))
+	assert.Equal(t, "x", b.Cell(2, 1))
+	assert.Equal(t, "x", b.Cell(4, 1))
+	assert.Equal(t, " ", b.Cell(5, 1))
+	assert.Equal(t, "y", b.Cell(0, 0))
+}
+
+func TestClipForm(t *testing.T) {
+	gui := NewGUI(30, 6)
+	gui.Begin()
+	b := gui.buffer
+	c := b.cells[0]
+	gui.Text("top")
+	gui.BeginForm()
+	assert.Equal(t, c.y+1, b.clip.y)
+	b.WriteEx(c.x, c.y, "above", 0)
+	gui.EndForm()
+	assert.Equal(t, 0, b.clip.y)
+	gui.End()
+
+	assert.Equal(t, "t", b.Cell(c.x, c.y))
+	assert.Equal(t, " ", b.Cell(c.x+3, c.y))
+}
+
+func TestClipChild(t *testing.T) {
+	gui := NewGUI(30, 6)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCell()
+	gui.BeginChild(4, 1)
+	gui.Text("Hello World")
+	gui.Text("second")
+	gui.EndChild()
+	gui.Text("ab")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	b := gui.buffer
+	c := b.cells[len(b.cells)-1]
+	assert.Equal(t, 6, c.w)
+	assert.Equal(t, "l", b.Cell(c.x+3, c.y))
+	assert.Equal(t, " ", b.Cell(c.x+4, c.y))
+	assert.Equal(t, "a", b.Cell(c.x, c.y+1))
+}
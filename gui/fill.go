@@ -0,0 +1,102 @@
+package imgui
+
+// Fills paint a background below the content. The background of a filled
+// slot is kept when text without an own background is written on top of
+// it. Fills are drawn before the borders and all other commands.
+
+// NO_FILL marks a slot without a fill
+const NO_FILL = -1
+
+// FillRect fills the rectangle with spaces of the style. The position is
+// absolute like WriteEx and clipped by the active clip region
+func (b *Buffer) FillRect(x, y, w, h int, style int) {
+	for i := 0; i < h; i++ {
+		b.commands = append(b.commands, DrawCommand{
+			uid:     b.uids.Top(),
+			style:   style,
+			x:       x,
+			y:       y + i,
+			size:    w,
+			cellIdx: -1,
+			clip:    b.topClip(),
+			fill:    true,
+		})
+	}
+}
+
+// fillLine fills w columns at the current position inside the current
+// cell without moving the cursor
+func (b *Buffer) fillLine(w int, style int) {
+	b.commands = append(b.commands, DrawCommand{
+		uid:     b.uids.Top(),
+		style:   style,
+		x:       b.curX,
+		y:       b.curY,
+		size:    w,
+		cellIdx: b.curCell,
+		clip:    b.topClip(),
+		fill:    true,
+	})
+}
+
+// fill sets the background of w columns starting at x, y
+func (b *Buffer) fill(x, y, w int, style int) {
+	if y < 0 || y >= b.height {
+		return
+	}
+	for i := max(x, 0); i < x+w && i < b.width; i++ {
+		if !b.clipped(i, y, 1) {
+			b.fills[y*b.width+i] = style
+			b.setCluster(i, y, " ", 1, style)
+		}
+	}
+}
+
+// withFill returns the style with the background of the fill if the
+// style has no background of its own
+func (b *Buffer) withFill(style, fill int) int {
+	if fill == NO_FILL || style == fill {
+		return style
+	}
+	key := [2]int{style, fill}
+	if id, ok := b.filled[key]; ok {
+		return id
+	}
+	st := b.theme.Style(style)
+	fs := b.theme.Style(fill)
+	id := style
+	if !st.Has(ATTR_BACKGROUND) && fs.Has(ATTR_BACKGROUND) {
+		st.background = fs.background
+		st.flags |= ATTR_BACKGROUND
+		id = AddStyle(st)
+	}
+	b.filled[key] = id
+	return id
+}
+
+// drawFills paints the backgrounds of the cells and all fill commands
+func (b *Buffer) drawFills() {
+	clear(b.filled)
+	b.setClip(-1)
+	for _, c := range b.cells {
+		// a text without a background looks the same as no fill
+		if c.opts.Background == NO_FILL || (c.opts.Background == ROLE_TEXT && !b.theme.Text.Has(ATTR_BACKGROUND)) {
+			continue
+		}
+		for y := c.y; y < c.y+c.h; y++ {
+			b.fill(c.x, y, c.w-1, c.opts.Background)
+		}
+	}
+	for _, c := range b.commands {
+		if c.fill {
+			b.setClip(c.clip)
+			b.fill(c.x, c.y, c.size, c.style)
+		}
+	}
+	b.setClip(-1)
+}
+
+// FillRect fills the rectangle with the style. The position is absolute
+func (g *GUI) FillRect(x, y, w, h int, style int) {
+	g.buffer.FillRect(x, y, w, h, style)
+}
@@ -0,0 +1,134 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/amecky/table/table"
+)
+
+func TestFillRect(t *testing.T) {
+	gui := NewGUI(20, 6)
+	gui.Begin()
+	gui.PushPopup(0, 0, 20, 6)
+	gui.FillRect(0, 5, 20, 1, ROLE_CODE)
+	gui.buffer.WriteEx(1, 5, "status", ROLE_DANGER)
+	gui.PopClip()
+	gui.End()
+
+	b := gui.buffer
+	_, st := b.At(10, 5)
+	assert.Equal(t, ROLE_CODE, st)
+	// the text keeps the background of the fill
+	_, st = b.At(1, 5)
+	s := customStyle(st)
+	assert.Equal(t, DarkTheme().Danger.foreground, s.foreground)
+	assert.Equal(t, DarkTheme().Code.background, s.background)
+}
+
+func TestFillText(t *testing.T) {
+	gui := NewGUI(20, 6)
+	theme := DarkTheme()
+	theme.Text = NewStyle("#ffffff", "#102030", false)
+	gui.SetTheme(theme)
+	gui.Begin()
+	gui.PushPopup(0, 0, 20, 6)
+	gui.FillRect(0, 5, 20, 1, ROLE_TEXT)
+	gui.buffer.WriteEx(1, 5, "status", ROLE_DANGER)
+	gui.PopClip()
+	gui.End()
+
+	// a fill with the text role is kept below other text
+	_, st := gui.buffer.At(1, 5)
+	assert.Equal(t, Hex("#102030"), customStyle(st).background)
+}
+
+func TestCellBackground(t *testing.T) {
+	gui := NewGUI(20, 6)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCellEx("", CellOptions{Width: 8, Background: ROLE_CODE})
+	gui.Text("abc")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	b := gui.buffer
+	c := b.cells[len(b.cells)-1]
+	bg := b.theme.Code.background
+	_, st := b.At(c.x+6, c.y)
+	assert.Equal(t, ROLE_CODE, st)
+	_, st = b.At(c.x, c.y)
+	assert.Equal(t, bg, customStyle(st).background)
+	// the border keeps its style
+	_, st = b.At(c.x+c.w-1, c.y)
+	assert.Equal(t, ROLE_BORDER, st)
+}
+
+func TestTableShading(t *testing.T) {
+	gui := NewGUI(40, 10)
+	gui.Begin()
+	tbl := table.New().Headers("Name", "Value")
+	for _, n := range []string{"one", "two", "three"} {
+		row := tbl.CreateRow()
+		row.AddDefaultText(n)
+		row.AddDefaultText("1")
+	}
+	gui.TableEx(tbl, TableOptions{ShadeRows: true})
+	gui.End()
+
+	b := gui.buffer
+	c := b.cells[0]
+	odd := b.theme.RowOdd.background
+	// header, separator and the first row are not shaded
+	_, st := b.At(c.x+1, c.y+2)
+	assert.NotEqual(t, odd, customStyle(st).background)
+	_, st = b.At(c.x+1, c.y+3)
+	assert.Equal(t, odd, customStyle(st).background)
+	_, st = b.At(c.x+12, c.y+3)
+	assert.Equal(t, odd, customStyle(st).background)
+	_, st = b.At(c.x+1, c.y+4)
+	assert.NotEqual(t, odd, customStyle(st).background)
+}
+
+func TestTableNoShading(t *testing.T) {
+	gui := NewGUI(40, 10)
+	gui.Begin()
+	tbl := table.New().Headers("Name", "Value")
+	for _, n := range []string{"one", "two", "three"} {
+		row := tbl.CreateRow()
+		row.AddDefaultText(n)
+		row.AddDefaultText("1")
+	}
+	gui.Table(tbl)
+	gui.End()
+
+	b := gui.buffer
+	c := b.cells[0]
+	_, st := b.At(c.x+1, c.y+3)
+	assert.Equal(t, 0, st)
+}
+
+func TestCellBackgroundNoFill(t *testing.T) {
+	theme := DarkTheme()
+	theme.Text = NewStyle("#ffffff", "#102030", false)
+	gui := NewGUI(30, 6)
+	gui.SetTheme(theme)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCellEx("", CellOptions{Width: 8})
+	gui.Text("abc")
+	gui.EndCell()
+	gui.StartCellEx("", CellOptions{Width: 8, Background: NO_FILL})
+	gui.Text("abc")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	b := gui.buffer
+	// the default fills the cell with the text background
+	c := b.cells[len(b.cells)-2]
+	assert.Equal(t, ROLE_TEXT, b.fills[c.y*b.width+c.x+6])
+	c = b.cells[len(b.cells)-1]
+	assert.Equal(t, NO_FILL, b.fills[c.y*b.width+c.x+6])
+}
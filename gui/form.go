@@ -0,0 +1,140 @@
+package imgui
+
+import (
+	"github.com/amecky/table/table"
+)
+
+type FormOptions struct {
+	// RightAlign aligns the labels to the right of the first column
+	RightAlign bool
+}
+
+// formRow is the label command and the index of the first command
+// of the row in the buffer
+type formRow struct {
+	label string
+	cmd   int
+}
+
+// formState collects the labels of a form. The width of the label column
+// is taken from the last frame so the controls can be hit tested and is
+// corrected in EndForm if a label got wider
+type formState struct {
+	opts  FormOptions
+	idx   int
+	width int
+	rows  []formRow
+	// the clip region of the form
+	clip int
+}
+
+// BeginForm starts a form. All labelled widgets until EndForm are
+// aligned in two columns with the labels in the first column
+func (g *GUI) BeginForm() {
+	g.BeginFormEx(FormOptions{})
+}
+
+// BeginFormEx starts a form with options. A form which is still
+// open is closed first
+func (g *GUI) BeginFormEx(opts FormOptions) {
+	if g.form != nil {
+		g.EndForm()
+	}
+	width := 0
+	if g.formIdx < len(g.formWidths) {
+		width = g.formWidths[g.formIdx]
+	}
+	b := g.buffer
+	g.form = &formState{
+		opts:  opts,
+		idx:   g.formIdx,
+		width: width,
+		clip:  b.pushAnchoredClip(b.lineStart(), b.curY, b.width, b.height, false),
+	}
+	g.formIdx++
+	g.pushScope(SCOPE_FORM)
+}
+
+func (g *GUI) EndForm() {
+	g.popScope(SCOPE_FORM)
+}
+
+// formatLabel pads the label to the width of the label column
+func (f *formState) formatLabel(label string, width int) string {
+	align := table.AlignLeft
+	if f.opts.RightAlign {
+		align = table.AlignRight
+	}
+	return formatString(label, width, align) + " "
+}
+
+// label writes the label of a widget. Inside a form it is padded
+// to the width of the label column
+func (g *GUI) label(label string) {
+	if g.form == nil {
+		g.buffer.Write(label+" ", 0, true)
+		return
+	}
+	f := g.form
+	// every labelled widget starts a new line
+	if len(f.rows) > 0 {
+		prev := g.buffer.commands[f.rows[len(f.rows)-1].cmd]
+		if g.buffer.curY <= prev.y {
+			g.buffer.curY = max(prev.y, g.buffer.lastY) + 1
+			g.buffer.curX = g.buffer.lineStart()
+		}
+	}
+	f.rows = append(f.rows, formRow{label: label, cmd: len(g.buffer.commands)})
+	g.buffer.Write(f.formatLabel(label, max(f.width, internalLen(label))), 0, true)
+}
+
+// FormHelp writes a help text behind the last widget of the form row
+func (g *GUI) FormHelp(text string) {
+	g.buffer.PushID("FORM_HELP")
+	g.buffer.SameLine(0)
+	g.buffer.Write(text, ROLE_TEXT_DIM, false)
+	g.buffer.PopID()
+}
+
+// endForm aligns all rows to the widest label and keeps
+// the width for the next frame
+func (g *GUI) endForm() {
+	f := g.form
+	if f == nil {
+		return
+	}
+	g.form = nil
+	g.buffer.popClipTo(f.clip)
+	width := 0
+	for _, r := range f.rows {
+		width = max(width, internalLen(r.label))
+	}
+	for len(g.formWidths) <= f.idx {
+		g.formWidths = append(g.formWidths, 0)
+	}
+	g.formWidths[f.idx] = width
+	// continue below the form
+	if len(f.rows) > 0 && g.buffer.curY <= g.buffer.lastY {
+		g.buffer.curY = g.buffer.lastY + 1
+		g.buffer.curX = g.buffer.lineStart()
+	}
+	cmds := g.buffer.commands
+	for i, r := range f.rows {
+		lc := &cmds[r.cmd]
+		d := width + 1 - lc.size
+		if d == 0 {
+			continue
+		}
+		lc.text = f.formatLabel(r.label, width)
+		lc.size = width + 1
+		end := len(cmds)
+		if i < len(f.rows)-1 {
+			end = f.rows[i+1].cmd
+		}
+		for j := r.cmd + 1; j < end; j++ {
+			if cmds[j].cellIdx == lc.cellIdx {
+				cmds[j].x += d
+			}
+		}
+	}
+}
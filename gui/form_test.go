@@ -0,0 +1,65 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func renderForm(gui *GUI, opts FormOptions) {
+	gui.Begin()
+	gui.BeginFormEx(opts)
+	gui.Input("Name:", "Tesla", false, 10)
+	gui.FormHelp("the ticker")
+	gui.Selection("Intervall:", []string{"5m", "15m"}, 0)
+	gui.IntSlider("Num:", 0, 10, 5, 1)
+	gui.EndForm()
+	gui.Text("Below")
+	gui.End()
+}
+
+func TestFormAlignment(t *testing.T) {
+	gui := NewGUI(60, 10)
+	for i := 0; i < 2; i++ {
+		renderForm(gui, FormOptions{})
+		assert.NoError(t, gui.Err())
+		for y := 1; y <= 3; y++ {
+			r, _ := gui.buffer.At(11, y)
+			assert.Equal(t, ' ', r)
+		}
+		r, _ := gui.buffer.At(12, 1)
+		assert.Equal(t, 'T', r)
+		r, _ = gui.buffer.At(12, 2)
+		assert.Equal(t, '⯇', r)
+		r, _ = gui.buffer.At(12, 3)
+		assert.Equal(t, '⯇', r)
+		r, _ = gui.buffer.At(23, 1)
+		assert.Equal(t, 't', r)
+	}
+	assert.Equal(t, []int{10}, gui.formWidths)
+	r, _ := gui.buffer.At(1, 4)
+	assert.Equal(t, 'B', r)
+}
+
+func TestFormRightAlign(t *testing.T) {
+	gui := NewGUI(60, 10)
+	renderForm(gui, FormOptions{RightAlign: true})
+	r, _ := gui.buffer.At(7, 3)
+	assert.Equal(t, 'N', r)
+	r, _ = gui.buffer.At(12, 3)
+	assert.Equal(t, '⯇', r)
+}
+
+func TestFormReopen(t *testing.T) {
+	gui := NewGUI(60, 10)
+	gui.SetDebug(true)
+	gui.Begin()
+	gui.BeginForm()
+	gui.Input("Name:", "Tesla", false, 10)
+	gui.BeginForm()
+	gui.Input("Ticker:", "TSLA", false, 10)
+	gui.EndForm()
+	gui.End()
+	assert.NoError(t, gui.Err())
+	assert.Equal(t, 0, len(gui.scopes))
+}
@@ -41,6 +41,8 @@ func (r rect) Inside(px, py int) bool {
 	return px >= r.x && px <= r.x+r.w && py >= r.y && py <= r.y+r.h
 }
 
+const INDENT_SIZE = 2
+
 type GUI struct {
 	width      int
 	height     int
@@ -60,6 +62,20 @@ type GUI struct {
 	menu Menu
 
 	started bool
+
+	candles    map[string]*candleState
+	splits     map[string]*splitState
+	splitStack []*splitState
+	mouseDown  bool
+	clicked    bool
+	form       *formState
+	formWidths []int
+	formIdx    int
+
+	debug    bool
+	scopes   []scope
+	problems []string
+	err      error
 }
 
 func NewGUI(w, h int) *GUI {
@@ -68,35 +84,58 @@ func NewGUI(w, h int) *GUI {
 		height:    h,
 		processed: -1,
 		buffer:    NewBuffer(w, h),
+		candles:   make(map[string]*candleState),
+		splits:    make(map[string]*splitState),
 	}
 }
 
 func (g *GUI) Begin() {
 	g.buffer.Clear()
 	g.started = true
-	g.StartRow()
+	g.scopes = g.scopes[:0]
+	g.formIdx = 0
+	g.buffer.StartRow()
 	g.buffer.StartCell()
+	g.scopes = append(g.scopes, scope{kind: SCOPE_ROW, implicit: true}, scope{kind: SCOPE_CELL, implicit: true})
 	g.menuPos = 0
 }
 
+// End closes all open rows, cells, groups, menus and ID scopes and
+// returns the rendered frame. Unbalanced calls are available via Err
 func (g *GUI) End() string {
-	if g.buffer.grouping {
-		g.EndGroup()
+	g.finish()
+	return g.buffer.String()
+}
+
+// finish closes the frame and resolves the layout
+func (g *GUI) finish() {
+	g.closeAll()
+	g.buffer.Layout()
+	if g.clicked {
+		g.buffer.focusAt(g.mouseEvent.X, g.mouseEvent.Y)
+		g.clicked = false
 	}
-	g.buffer.EndCell()
-	g.buffer.EndRow()
+	g.finishProblems()
 	g.processed = -1
-	return g.buffer.String()
 }
 
 func (g *GUI) SetMouseEvent(e tea.MouseEvent) {
 	g.mouseEvent = e
 	g.processed = 1
+	g.mouseDown = e.Button == tea.MouseButtonLeft
+	g.clicked = g.mouseDown
 }
 
 func (g *GUI) SetMousePos(e tea.MouseEvent) {
 	g.mouseX = e.X
 	g.mouseY = e.Y
+	g.mouseDown = e.Button == tea.MouseButtonLeft
+}
+
+func (g *GUI) SetMouseRelease(e tea.MouseEvent) {
+	g.mouseX = e.X
+	g.mouseY = e.Y
+	g.mouseDown = false
 }
 
 func (g *GUI) SendKey(s string) {
@@ -118,15 +157,22 @@ func (g *GUI) Debug() {
 	g.buffer.Debug()
 }
 
+// Text writes the text. Every line of a text containing newlines
+// is written in its own row
 func (g *GUI) Text(text string) {
 	g.buffer.PushID(text)
-	g.buffer.Write(text, 0, false)
+	for _, l := range strings.Split(text, "\n") {
+		g.buffer.Write(l, 0, false)
+	}
 	g.buffer.PopID()
 }
 
 func (g *GUI) Button(text string) bool {
 	g.buffer.PushID("BUTTON_" + text)
-	g.buffer.Write(" "+text+" ", OK_BUTTON_STYLE, false)
+	g.buffer.Write(" "+text+" ", ROLE_BUTTON, false)
+	if g.buffer.HasFocus(g.mouseX, g.mouseY) {
+		g.buffer.commands[len(g.buffer.commands)-1].style = ROLE_BUTTON_HOVER
+	}
 	ret := false
 	if g.processed == 1 && g.buffer.HasFocus(g.mouseEvent.X, g.mouseEvent.Y) {
 		log.Println("Button " + text + " pressed")
@@ -141,9 +187,9 @@ func (g *GUI) Button(text string) bool {
 // Returns the index of the selected item
 func (g *GUI) Selection(label string, lines []string, selected int) int {
 	g.buffer.PushID("SELECTION_" + lines[0])
+	g.label(label)
 	curPos := g.buffer.CurrentPos()
 	sel := selected
-	curPos.x += internalLen(label) + 1
 	if g.processed == 1 && curPos.Matches(g.mouseEvent.X, g.mouseEvent.Y) {
 		g.processed = -1
 		sel--
@@ -161,19 +207,18 @@ func (g *GUI) Selection(label string, lines []string, selected int) int {
 		}
 	}
 
-	g.buffer.Write(label+" ", 0, true)
-	g.buffer.Write("⯇", ARROW_STYLE, true)
+	g.buffer.Write("⯇", ROLE_HIGHLIGHT, true)
 	txt := formatString(" "+lines[sel]+" ", l, table.AlignCenter)
-	g.buffer.Write(txt, INPUT_STYLE, true)
-	g.buffer.Write("⯈", ARROW_STYLE, true)
+	g.buffer.Write(txt, ROLE_INPUT, true)
+	g.buffer.Write("⯈", ROLE_HIGHLIGHT, true)
 	g.buffer.PopID()
 	return sel
 }
 
 func (g *GUI) IntSlider(label string, min, max, value, steps int) int {
 	g.buffer.PushID("INT_SLIDE_" + fmt.Sprintf(" %d ", value))
-	g.buffer.Write(label+" ", 0, true)
-	g.buffer.Write("⯇", ARROW_STYLE, true)
+	g.label(label)
+	g.buffer.Write("⯇", ROLE_HIGHLIGHT, true)
 	if g.processed == 1 && g.buffer.HasFocus(g.mouseEvent.X, g.mouseEvent.Y) {
 		value -= steps
 		if value < min {
@@ -182,8 +227,8 @@ func (g *GUI) IntSlider(label string, min, max, value, steps int) int {
 		g.processed = -1
 	}
 	l := len(fmt.Sprintf("%d", max)) + 2
-	g.buffer.Write(formatString(fmt.Sprintf(" %d ", value), l, table.AlignCenter), INPUT_STYLE, true)
-	g.buffer.Write("⯈", ARROW_STYLE, true)
+	g.buffer.Write(formatString(fmt.Sprintf(" %d ", value), l, table.AlignCenter), ROLE_INPUT, true)
+	g.buffer.Write("⯈", ROLE_HIGHLIGHT, true)
 	if g.processed == 1 && g.buffer.HasFocus(g.mouseEvent.X, g.mouseEvent.Y) {
 		value += steps
 		if value > max {
@@ -198,9 +243,9 @@ func (g *GUI) IntSlider(label string, min, max, value, steps int) int {
 func (g *GUI) Checkbox(label string, active bool) bool {
 	g.buffer.PushID("CHECKBOX_" + label)
 	if active {
-		g.buffer.Write("■", ARROW_STYLE, true)
+		g.buffer.Write("■", ROLE_HIGHLIGHT, true)
 	} else {
-		g.buffer.Write("▢", ARROW_STYLE, true)
+		g.buffer.Write("▢", ROLE_HIGHLIGHT, true)
 	}
 	if g.processed == 1 && g.buffer.HasFocus(g.mouseEvent.X, g.mouseEvent.Y) {
 		if active {
@@ -217,7 +262,7 @@ func (g *GUI) Checkbox(label string, active bool) bool {
 
 func (g *GUI) Radio(label string, entries []string, selected int) int {
 	g.buffer.PushID("RADIO_" + label)
-	g.buffer.Write(label+" ", 0, true)
+	g.label(label)
 	ret := selected
 	curPos := g.buffer.CurrentPos()
 	for i := 0; i < len(entries); i++ {
@@ -226,9 +271,9 @@ func (g *GUI) Radio(label string, entries []string, selected int) int {
 			g.processed = -1
 		}
 		if i == ret {
-			g.buffer.Write("■", ARROW_STYLE, true)
+			g.buffer.Write("■", ROLE_HIGHLIGHT, true)
 		} else {
-			g.buffer.Write("▢", ARROW_STYLE, true)
+			g.buffer.Write("▢", ROLE_HIGHLIGHT, true)
 		}
 
 		g.buffer.Write(" "+entries[i]+" ", 0, true)
@@ -240,11 +285,11 @@ func (g *GUI) Radio(label string, entries []string, selected int) int {
 
 func (g *GUI) DropDown(label string, lines []string, selected int, active bool) (int, bool) {
 	g.buffer.PushID("DROPDOWN_" + lines[0])
-	g.buffer.Write(label+" ", 0, true)
+	g.label(label)
 	if active {
-		g.buffer.Write("⯆", ARROW_STYLE, true)
+		g.buffer.Write("⯆", ROLE_HIGHLIGHT, true)
 	} else {
-		g.buffer.Write("⯈", ARROW_STYLE, true)
+		g.buffer.Write("⯈", ROLE_HIGHLIGHT, true)
 	}
 	if g.processed == 1 && g.buffer.HasFocus(g.mouseEvent.X, g.mouseEvent.Y) {
 		if active {
@@ -280,7 +325,7 @@ func (g *GUI) DropDown(label string, lines []string, selected int, active bool)
 
 func (g *GUI) Input(label, text string, active bool, size int) (string, bool) {
 	g.buffer.PushID("INPUT_" + label)
-	g.buffer.Write(label+" ", 0, true)
+	g.label(label)
 	ret := text
 	if active && !g.saveInput {
 		active = false
@@ -299,10 +344,10 @@ func (g *GUI) Input(label, text string, active bool, size int) (string, bool) {
 		t += strings.Repeat(" ", d)
 	}
 	if active {
-		g.buffer.Write(t, INPUT_ACTIVE_STYLE, true)
+		g.buffer.Write(t, ROLE_INPUT_ACTIVE, true)
 		ret = g.input
 	} else {
-		g.buffer.Write(t, INPUT_STYLE, true)
+		g.buffer.Write(t, ROLE_INPUT, true)
 	}
 	if g.processed == 1 && g.buffer.HasFocus(g.mouseEvent.X, g.mouseEvent.Y) {
 		if active {
@@ -326,20 +371,75 @@ func (g *GUI) Input(label, text string, active bool, size int) (string, bool) {
 
 func (g *GUI) StartGroup() {
 	g.buffer.grouping = true
+	g.pushScope(SCOPE_GROUP)
 }
 
 func (g *GUI) EndGroup() {
+	g.popScope(SCOPE_GROUP)
+}
+
+func (g *GUI) endGroup() {
 	g.buffer.grouping = false
 	g.buffer.curY++
-	g.buffer.curX = 0
+	g.buffer.curX = g.buffer.lineStart()
+}
+
+// SameLine places the next widget on the line of the previous one. With
+// an offset of zero the widget starts one column after the previous one
+// otherwise offset columns from the start of the cell
+func (g *GUI) SameLine(offset int) {
+	g.buffer.SameLine(offset)
+}
+
+// Spacing adds an empty line
+func (g *GUI) Spacing() {
+	g.buffer.Reserve(0, 1)
+}
+
+// Indent moves all following lines of the current cell n columns to the
+// right. If n is zero INDENT_SIZE is used
+func (g *GUI) Indent(n int) {
+	if n <= 0 {
+		n = INDENT_SIZE
+	}
+	g.buffer.Indent(n)
+}
+
+// Unindent reverts Indent
+func (g *GUI) Unindent(n int) {
+	if n <= 0 {
+		n = INDENT_SIZE
+	}
+	g.buffer.Indent(-n)
+}
+
+// Separator draws a horizontal line spanning the current cell
+func (g *GUI) Separator() {
+	g.buffer.Separator()
+}
+
+// Dummy reserves an empty area of w x h cells
+func (g *GUI) Dummy(w, h int) {
+	g.buffer.Reserve(w, h)
+}
+
+// SetCursorPos moves the cursor relative to the top left corner of the current cell
+func (g *GUI) SetCursorPos(x, y int) {
+	g.buffer.SetCursorPos(x, y)
 }
 
 func (g *GUI) StartRow() {
+	// the first row is already started in Begin
+	if g.started {
+		g.claimScope(SCOPE_ROW)
+		return
+	}
 	g.buffer.StartRow()
+	g.pushScope(SCOPE_ROW)
 }
 
 func (g *GUI) EndRow() {
-	g.buffer.EndRow()
+	g.popScope(SCOPE_ROW)
 }
 
 func (g *GUI) StartCell() {
@@ -347,32 +447,45 @@ func (g *GUI) StartCell() {
 }
 
 func (g *GUI) StartCellWithHeader(title string) {
+	g.StartCellEx(title, CellOptions{})
+}
+
+// StartCellEx starts a new cell with the given title and size options
+func (g *GUI) StartCellEx(title string, opts CellOptions) {
 	if g.started {
 		g.started = false
+		g.claimScope(SCOPE_CELL)
 		if len(g.buffer.cells) > 0 {
 			c := &g.buffer.cells[0]
 			c.title = title
+			c.opts = opts
 		}
 	} else {
-		g.buffer.StartCellWithHeader(title)
+		g.buffer.StartCellEx(title, opts)
+		g.pushScope(SCOPE_CELL)
 	}
 }
 
 func (g *GUI) EndCell() {
-	g.buffer.EndCell()
+	g.popScope(SCOPE_CELL)
 }
 
 func (g *GUI) BeginMenuBar() {
 	g.useMenu = true
 	g.buffer.useMenu = true
+	g.pushScope(SCOPE_MENUBAR)
 }
 
 func (g *GUI) EndMenuBar() {
+	g.popScope(SCOPE_MENUBAR)
 }
 
 func (g *GUI) BeginMenu(label string) bool {
 	id := "MENU_" + label
+	g.pushScope(SCOPE_MENU)
 	g.itemPos = 1
+	// the menu is drawn on top of the cells
+	g.buffer.PushPopup(g.menuPos, 0, g.width-g.menuPos, g.height)
 	g.buffer.WriteEx(g.menuPos, 0, " "+label+" ", 1)
 	ret := false
 	if g.menu.active == id {
@@ -393,8 +506,12 @@ func (g *GUI) BeginMenu(label string) bool {
 }
 
 func (g *GUI) EndMenu() {
-	g.menuPos += g.menuSize
+	g.popScope(SCOPE_MENU)
+}
 
+func (g *GUI) endMenu() {
+	g.buffer.PopClip()
+	g.menuPos += g.menuSize
 }
 
 func (g *GUI) MenuItem(label string) bool {
@@ -423,6 +540,28 @@ func (g *GUI) MenuItem(label string) bool {
 }
 
 func (g *GUI) Table(rt *table.Table) {
+	g.TableWithConverter(rt, DefaultMarkerConverter)
+}
+
+// TableWithConverter draws the table and uses the converter to map
+// the marker and text of every cell to a style
+func (g *GUI) TableWithConverter(rt *table.Table, conv MarkerConverter) {
+	g.TableEx(rt, TableOptions{Converter: conv})
+}
+
+type TableOptions struct {
+	// Converter maps the marker and text of every cell to a style. If
+	// it is nil the DefaultMarkerConverter is used
+	Converter MarkerConverter
+	// ShadeRows fills every second row with ROLE_ROW_ODD
+	ShadeRows bool
+}
+
+func (g *GUI) TableEx(rt *table.Table, opts TableOptions) {
+	conv := opts.Converter
+	if conv == nil {
+		conv = DefaultMarkerConverter
+	}
 	var sizes = make([]int, 0)
 	for _, th := range rt.TableHeaders {
 		sizes = append(sizes, internalLen(th.Text))
@@ -455,18 +594,13 @@ func (g *GUI) Table(rt *table.Table) {
 	}
 	g.buffer.Write(rt.BorderStyle.RIGHT_DEL, 0, false)
 
-	for _, r := range rt.Rows {
+	width := total + internalLen(rt.BorderStyle.H_LINE)*(len(sizes)+1)
+	for j, r := range rt.Rows {
+		if opts.ShadeRows && j%2 == 1 {
+			g.buffer.fillLine(width, ROLE_ROW_ODD)
+		}
 		for i, c := range r.Cells {
-			st := c.Marker
-			if st != 0 {
-				if st == -1 {
-					st = TABLE_RED
-				} else if st == 1 {
-					st = TABLE_LIGHT_GREEN
-				} else {
-					st = TABLE_RED - 2 + st
-				}
-			}
+			st := conv(c.Marker, c.Text)
 			g.buffer.Write(rt.BorderStyle.H_LINE, 0, true)
 			g.buffer.Write(strings.Repeat(" ", rt.PaddingSize), 0, true)
 			str := formatString(c.Text, sizes[i], c.Alignment)
@@ -0,0 +1,153 @@
+package imgui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/amecky/table/table"
+)
+
+// Palette is a list of hex colors defining a gradient. The first color
+// is used for the minimum and the last color for the maximum value
+type Palette []string
+
+var PALETTE_HEAT = Palette{"#1a7091", "#d3d7cf", "#a21a1a"}
+var PALETTE_GREEN = Palette{"#0c0c0c", "#287114", "#8ae234"}
+var PALETTE_CORRELATION = Palette{"#a21a1a", "#2a2a2a", "#389a1d"}
+
+// PALETTE_STEPS is the number of colors a palette is reduced to before
+// the colors are turned into styles. Every style is kept for the whole
+// process so the number of colors has to be limited
+const PALETTE_STEPS = 32
+
+// At returns the interpolated color at t (0..1). NaN is treated as 0
+func (p Palette) At(t float64) Color {
+	if len(p) == 0 {
+		return Hex(WHITE)
+	}
+	if len(p) == 1 || t <= 0 || math.IsNaN(t) {
+		return Hex(p[0])
+	}
+	if t >= 1 {
+		return Hex(p[len(p)-1])
+	}
+	seg := t * float64(len(p)-1)
+	idx := int(seg)
+	return Hex(p[idx]).Lerp(Hex(p[idx+1]), seg-float64(idx))
+}
+
+// step returns the color at t rounded to one of PALETTE_STEPS + 1 colors
+func (p Palette) step(t float64) Color {
+	return p.At(math.Round(t*PALETTE_STEPS) / PALETTE_STEPS)
+}
+
+// finite returns true if v is neither NaN nor infinite
+func finite(v float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// heatmapStyle returns a style with the palette color as background and
+// a foreground which is readable on top of it
+func heatmapStyle(c Color) int {
+	fg := Hex(BRIGHT_WHITE)
+	if c.Luminance() > 0.5 {
+		fg = Hex(BLACK)
+	}
+	return AddStyle(Style{foreground: fg, background: c, flags: ATTR_FOREGROUND | ATTR_BACKGROUND})
+}
+
+func valueRange(values [][]float64) (float64, float64) {
+	mn := math.Inf(1)
+	mx := math.Inf(-1)
+	for _, r := range values {
+		for _, v := range r {
+			if !finite(v) {
+				continue
+			}
+			mn = math.Min(mn, v)
+			mx = math.Max(mx, v)
+		}
+	}
+	if math.IsInf(mn, 1) {
+		return 0, 0
+	}
+	return mn, mx
+}
+
+func normalize(v, mn, mx float64) float64 {
+	if mx == mn {
+		return 0.5
+	}
+	return (v - mn) / (mx - mn)
+}
+
+// Heatmap draws a matrix of values with the background of every cell taken
+// from the palette. Below the matrix a legend is drawn together with the
+// value of the hovered cell. Returns the hovered value and true if the
+// mouse is over one of the cells
+func (g *GUI) Heatmap(label string, rows, cols []string, values [][]float64, palette Palette) (float64, bool) {
+	g.buffer.PushID("HEATMAP_" + label)
+	mn, mx := valueRange(values)
+	cw := findMaxLen(cols)
+	for _, r := range values {
+		for _, v := range r {
+			if l := internalLen(fmt.Sprintf("%.2f", v)); l > cw {
+				cw = l
+			}
+		}
+	}
+	cw += 2
+	rw := findMaxLen(rows) + 1
+	if label != "" {
+		g.buffer.Write(label, ROLE_HEADER, false)
+	}
+	g.buffer.Write(strings.Repeat(" ", rw), 0, true)
+	for _, c := range cols {
+		g.buffer.Write(formatString(c, cw, table.AlignCenter), 0, true)
+	}
+	g.buffer.Write("", 0, false)
+	hovered := false
+	hv := 0.0
+	hr := 0
+	hc := 0
+	for i, r := range rows {
+		g.buffer.Write(formatString(r, rw, table.AlignLeft), 0, true)
+		for j := range cols {
+			if i >= len(values) || j >= len(values[i]) || !finite(values[i][j]) {
+				g.buffer.Write(strings.Repeat(" ", cw), 0, true)
+				continue
+			}
+			v := values[i][j]
+			cp := g.buffer.CurrentPos()
+			st := heatmapStyle(palette.step(normalize(v, mn, mx)))
+			g.buffer.Write(formatString(fmt.Sprintf("%.2f", v), cw, table.AlignCenter), st, true)
+			r := rect{x: cp.x, y: cp.y, w: cw - 1, h: 0}
+			if r.Inside(g.mouseX, g.mouseY) {
+				hovered = true
+				hv = v
+				hr = i
+				hc = j
+			}
+		}
+		g.buffer.Write("", 0, false)
+	}
+	// legend
+	steps := cw * len(cols)
+	if steps < 10 {
+		steps = 10
+	}
+	g.buffer.Write(formatString(fmt.Sprintf("%.2f", mn), rw, table.AlignLeft), 0, true)
+	for i := 0; i < steps; i++ {
+		c := palette.step(float64(i) / float64(steps-1))
+		g.buffer.Write("█", AddStyle(Style{foreground: c, flags: ATTR_FOREGROUND}), true)
+	}
+	g.buffer.Write(fmt.Sprintf(" %.2f", mx), 0, false)
+	if hovered {
+		g.buffer.Write(fmt.Sprintf("%s / %s: %.2f", rows[hr], cols[hc], hv), 0, false)
+	} else {
+		g.buffer.Write(" ", 0, false)
+	}
+	g.buffer.PopID()
+	return hv, hovered
+}
@@ -0,0 +1,59 @@
+package imgui
+
+import (
+	"math"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPaletteAt(t *testing.T) {
+	p := Palette{"#000000", "#ffffff"}
+	assert.Equal(t, Hex("#000000"), p.At(0))
+	assert.Equal(t, Hex("#ffffff"), p.At(1))
+	assert.Equal(t, Hex("#808080"), p.At(0.5))
+	assert.Equal(t, Hex("#ffffff"), p.At(2))
+	assert.Equal(t, Hex("#000000"), p.At(math.NaN()))
+}
+
+func TestPaletteStep(t *testing.T) {
+	p := Palette{"#000000", "#ffffff"}
+	assert.Equal(t, p.At(0.5), p.step(0.51))
+	assert.Equal(t, p.At(0), p.step(math.NaN()))
+}
+
+func TestHeatmapNonFinite(t *testing.T) {
+	gui := NewGUI(40, 10)
+	gui.Begin()
+	gui.Heatmap("", []string{"A", "B"}, []string{"X", "Y"}, [][]float64{{1, math.Inf(1)}, {math.NaN(), math.Inf(-1)}}, PALETTE_HEAT)
+	gui.End()
+	mn, mx := valueRange([][]float64{{1, math.Inf(1)}, {3, math.Inf(-1)}})
+	assert.Equal(t, 1.0, mn)
+	assert.Equal(t, 3.0, mx)
+}
+
+func TestHeatmapStyles(t *testing.T) {
+	gui := NewGUI(40, 10)
+	gui.Begin()
+	gui.Heatmap("", []string{"A"}, []string{"X", "Y"}, [][]float64{{0, 1}}, PALETTE_HEAT)
+	gui.End()
+	n := len(customStyles.styles)
+	// new values reuse the styles of the palette steps
+	for i := 0; i < 1000; i++ {
+		gui.Begin()
+		gui.Heatmap("", []string{"A"}, []string{"X", "Y"}, [][]float64{{0, float64(i) / 1000}}, PALETTE_HEAT)
+		gui.End()
+	}
+	assert.True(t, len(customStyles.styles)-n <= 2*(PALETTE_STEPS+1))
+}
+
+func TestHeatmapHover(t *testing.T) {
+	gui := NewGUI(40, 10)
+	gui.Begin()
+	gui.SetMousePos(tea.MouseEvent{X: 10, Y: 3})
+	v, ok := gui.Heatmap("", []string{"A", "B"}, []string{"X", "Y"}, [][]float64{{1, 2}, {3, 4}}, PALETTE_HEAT)
+	gui.End()
+	assert.True(t, ok)
+	assert.Equal(t, 4.0, v)
+}
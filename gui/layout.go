@@ -0,0 +1,258 @@
+package imgui
+
+// The layout is a tree of rows containing cells which can contain rows
+// again. It is built in two passes. While the frame is recorded every
+// cell is measured by its content and nested rows when it is closed so
+// following siblings are already placed correctly. At the end of the frame
+// Layout resolves percentage and fill widths from the top down and moves
+// every cell together with its content to the final position.
+//
+// A cell has the position of the first character inside the border. The
+// width includes one column of padding and the right border is drawn at
+// x+w-1. A row stores the outer rectangle including all borders.
+
+type Row struct {
+	cells []int
+	// the cell containing this row or -1
+	parent int
+	rect
+}
+
+func (b *Buffer) StartRow() {
+	r := Row{parent: -1}
+	if len(b.openCells) > 0 {
+		// nested rows start at the current line of the cell
+		r.parent = b.openCells[len(b.openCells)-1]
+		r.x = b.lineStart()
+		r.y = b.curY
+		if b.curX != r.x {
+			r.y++
+		}
+	} else {
+		if b.useMenu {
+			r.y = 1
+		}
+		for _, pr := range b.rows {
+			if pr.parent == -1 && pr.y+pr.h > r.y {
+				r.y = pr.y + pr.h
+			}
+		}
+	}
+	b.rows = append(b.rows, r)
+	idx := len(b.rows) - 1
+	if r.parent != -1 {
+		pc := &b.cells[r.parent]
+		pc.rows = append(pc.rows, idx)
+	}
+	b.openRows = append(b.openRows, idx)
+}
+
+func (b *Buffer) StartCell() {
+	b.StartCellWithHeader("")
+}
+
+func (b *Buffer) StartCellWithHeader(title string) {
+	b.StartCellEx(title, CellOptions{})
+}
+
+func (b *Buffer) StartCellEx(title string, opts CellOptions) {
+	if len(b.openRows) == 0 {
+		b.StartRow()
+	}
+	ridx := b.openRows[len(b.openRows)-1]
+	cr := &b.rows[ridx]
+	c := cell{
+		title: title,
+		opts:  opts,
+		row:   ridx,
+	}
+	if len(cr.cells) > 0 {
+		// next to the previous cell in the same row
+		prev := b.cells[cr.cells[len(cr.cells)-1]]
+		c.x = prev.x + prev.w + 1
+		c.y = prev.y
+	} else {
+		c.x = cr.x + 1
+		c.y = cr.y + 1
+	}
+	b.cells = append(b.cells, c)
+	b.curCell = len(b.cells) - 1
+	b.pushCellClip(b.curCell)
+	b.curX = c.x
+	b.curY = c.y
+	cr.cells = append(cr.cells, b.curCell)
+	b.openCells = append(b.openCells, b.curCell)
+}
+
+// EndCell measures the current cell by its content and nested rows
+func (b *Buffer) EndCell() {
+	if len(b.openCells) == 0 {
+		return
+	}
+	cidx := b.openCells[len(b.openCells)-1]
+	b.openCells = b.openCells[:len(b.openCells)-1]
+	b.popCellClip(cidx)
+	cur := &b.cells[cidx]
+	cur.w = max(internalLen(cur.title), internalLen(cur.opts.Footer)) + 2
+	cur.h = 0
+	for _, c := range b.commands {
+		// a child is measured by its size
+		if c.cellIdx == cidx && !b.inChild(c.clip) {
+			if c.x-cur.x+c.size+2 > cur.w {
+				cur.w = c.x - cur.x + c.size + 2
+			}
+			h := c.y - cur.y
+			if h >= cur.h {
+				cur.h = h + 1
+			}
+		}
+	}
+	for _, ri := range cur.rows {
+		r := b.rows[ri]
+		if r.x+r.w-cur.x+1 > cur.w {
+			cur.w = r.x + r.w - cur.x + 1
+		}
+		if r.y+r.h-cur.y > cur.h {
+			cur.h = r.y + r.h - cur.y
+		}
+	}
+	if cur.opts.Width > 0 {
+		cur.w = cur.opts.Width + 1
+	}
+	if cur.w < cur.opts.MinWidth+1 {
+		cur.w = cur.opts.MinWidth + 1
+	}
+	if cur.opts.Height > 0 {
+		cur.h = cur.opts.Height
+	}
+	cur.mw = cur.w
+	// use the width of the last frame until the layout is resolved
+	if (cur.opts.Fill || cur.opts.Percent > 0) && cidx < len(b.resolved) && b.resolved[cidx] > 0 {
+		cur.w = b.resolved[cidx]
+	}
+	if len(b.openCells) > 0 {
+		b.curCell = b.openCells[len(b.openCells)-1]
+	}
+}
+
+// EndRow sets the height of all cells to the highest cell and
+// continues below the row if it was nested
+func (b *Buffer) EndRow() {
+	if len(b.openRows) == 0 {
+		return
+	}
+	ridx := b.openRows[len(b.openRows)-1]
+	b.openRows = b.openRows[:len(b.openRows)-1]
+	cr := &b.rows[ridx]
+	my := 0
+	for _, c := range cr.cells {
+		if b.cells[c].h > my {
+			my = b.cells[c].h
+		}
+	}
+	for _, c := range cr.cells {
+		b.cells[c].h = my
+	}
+	cr.w = 0
+	cr.h = 0
+	if len(cr.cells) > 0 {
+		last := b.cells[cr.cells[len(cr.cells)-1]]
+		cr.w = last.x + last.w - cr.x
+		cr.h = my + 2
+	}
+	if cr.parent != -1 {
+		b.curCell = cr.parent
+		b.curX = b.lineStart()
+		b.curY = cr.y + cr.h
+	}
+}
+
+// Layout is the second pass. It resolves the widths of all rows from
+// the top down and moves the cells to their final position
+func (b *Buffer) Layout() {
+	for i, r := range b.rows {
+		if r.parent == -1 {
+			b.placeRow(i, b.width-r.x)
+		}
+	}
+	b.alignCommands()
+	if cap(b.resolved) < len(b.cells) {
+		b.resolved = make([]int, len(b.cells))
+	}
+	b.resolved = b.resolved[:len(b.cells)]
+	for i, c := range b.cells {
+		b.resolved[i] = c.w
+	}
+}
+
+// placeRow distributes the available width among the cells of the row
+func (b *Buffer) placeRow(ridx, available int) {
+	cr := &b.rows[ridx]
+	if len(cr.cells) == 0 {
+		return
+	}
+	used := 0
+	fills := 0
+	for _, ci := range cr.cells {
+		c := &b.cells[ci]
+		c.w = c.mw
+		if c.opts.Percent > 0 {
+			c.w = available*c.opts.Percent/100 - 1
+			if c.w < c.opts.MinWidth+1 {
+				c.w = c.opts.MinWidth + 1
+			}
+		}
+		if c.opts.Fill {
+			fills++
+		}
+		used += c.w + 1
+	}
+	remaining := available - used
+	x := cr.x + 1
+	for _, ci := range cr.cells {
+		c := &b.cells[ci]
+		if c.opts.Fill && remaining > 0 {
+			d := remaining / fills
+			remaining -= d
+			fills--
+			c.w += d
+		}
+		b.moveCell(ci, x-c.x)
+		x += c.w + 1
+	}
+	last := b.cells[cr.cells[len(cr.cells)-1]]
+	cr.w = last.x + last.w - cr.x
+	for _, ci := range cr.cells {
+		c := b.cells[ci]
+		for _, ri := range c.rows {
+			b.placeRow(ri, c.w-1-(b.rows[ri].x-c.x))
+		}
+	}
+}
+
+// moveCell moves the cell and all its content including nested rows
+// horizontally
+func (b *Buffer) moveCell(idx, dx int) {
+	if dx == 0 {
+		return
+	}
+	c := &b.cells[idx]
+	c.x += dx
+	for i := range b.commands {
+		if b.commands[i].cellIdx == idx {
+			b.commands[i].x += dx
+		}
+	}
+	for _, cv := range b.canvases {
+		if cv.cell == idx {
+			cv.x += dx
+		}
+	}
+	for _, ri := range c.rows {
+		r := &b.rows[ri]
+		r.x += dx
+		for _, ci := range r.cells {
+			b.moveCell(ci, dx)
+		}
+	}
+}
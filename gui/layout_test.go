@@ -0,0 +1,88 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestNestedLayout(t *testing.T) {
+	gui := NewGUI(50, 16)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCellWithHeader("Side")
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("one")
+	gui.EndCell()
+	gui.EndRow()
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("two")
+	gui.Text("three")
+	gui.EndCell()
+	gui.EndRow()
+	gui.Text("below")
+	gui.EndCell()
+	gui.StartCellEx("Main", CellOptions{Fill: true})
+	gui.Text("main")
+	gui.EndCell()
+	gui.EndRow()
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("footer")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	side := gui.buffer.cells[0]
+	first := gui.buffer.cells[1]
+	second := gui.buffer.cells[2]
+	main := gui.buffer.cells[3]
+	footer := gui.buffer.cells[4]
+	assert.Equal(t, rect{x: 2, y: 2, w: 5, h: 1}, first.rect)
+	assert.Equal(t, rect{x: 2, y: 5, w: 7, h: 2}, second.rect)
+	// the side panel contains both nested rows and the text below
+	assert.Equal(t, 8, side.h)
+	r, _ := gui.buffer.At(1, 8)
+	assert.Equal(t, 'b', r)
+	// the main panel fills the remaining width and has the same height
+	assert.Equal(t, side.x+side.w+1, main.x)
+	assert.Equal(t, 50, main.x+main.w)
+	assert.Equal(t, side.h, main.h)
+	// the next row starts below the tallest cell
+	assert.Equal(t, side.y+side.h+2, footer.y)
+}
+
+func TestCursorHelpers(t *testing.T) {
+	gui := NewGUI(30, 12)
+	gui.Begin()
+	gui.Text("Name")
+	gui.SameLine(0)
+	gui.Text("Value")
+	gui.Separator()
+	gui.Indent(0)
+	gui.Text("Item")
+	gui.Unindent(0)
+	gui.Spacing()
+	gui.Text("End")
+	gui.SetCursorPos(11, 0)
+	gui.Text("X")
+	gui.Dummy(20, 1)
+	gui.End()
+	line := func(y int) string {
+		ret := ""
+		for x := 0; x < 14; x++ {
+			r, _ := gui.buffer.At(x, y)
+			ret += string(r)
+		}
+		return ret
+	}
+	assert.Equal(t, "│Name Value X ", line(1))
+	assert.Equal(t, "├─────────────", line(2))
+	assert.Equal(t, "│  Item       ", line(3))
+	assert.Equal(t, "│             ", line(4))
+	assert.Equal(t, "│End          ", line(5))
+	// Dummy makes the cell 20 columns wide
+	assert.Equal(t, 22, gui.buffer.cells[0].w)
+}
@@ -0,0 +1,358 @@
+package imgui
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/amecky/table/table"
+)
+
+var (
+	mdHeading   = regexp.MustCompile(`^(#{1,6})\s+(.*?)\s*#*\s*$`)
+	mdList      = regexp.MustCompile(`^(\s*)([-*+]|\d+[.)])\s+(.*)$`)
+	mdRule      = regexp.MustCompile(`^\s*([-*_])(\s*([-*_])){2,}\s*$`)
+	mdTableSep  = regexp.MustCompile(`^:?-+:?$`)
+	mdFence     = regexp.MustCompile("^\\s*(```|~~~)")
+	mdQuote     = regexp.MustCompile(`^\s*>\s?(.*)$`)
+	mdTableLine = regexp.MustCompile(`^\s*\|`)
+)
+
+// markdownInline converts the inline markdown of a line into
+// the markup of TextRich
+func markdownInline(s string) string {
+	var sb strings.Builder
+	r := []rune(s)
+	bold := false
+	italic := false
+	isWord := func(i int) bool {
+		return i >= 0 && i < len(r) && (unicode.IsLetter(r[i]) || unicode.IsDigit(r[i]))
+	}
+	for i := 0; i < len(r); i++ {
+		c := r[i]
+		switch {
+		case c == '\\' && i+1 < len(r):
+			i++
+			if r[i] == '[' {
+				sb.WriteString("[[")
+			} else {
+				sb.WriteRune(r[i])
+			}
+		case c == '`':
+			end := i + 1
+			for end < len(r) && r[end] != '`' {
+				end++
+			}
+			if end == len(r) {
+				sb.WriteRune(c)
+				continue
+			}
+			sb.WriteString("[code]")
+			sb.WriteString(strings.ReplaceAll(string(r[i+1:end]), "[", "[["))
+			sb.WriteString("[/]")
+			i = end
+		case c == '[':
+			rest := string(r[i:])
+			mid := strings.Index(rest, "](")
+			end := strings.Index(rest, ")")
+			if mid == -1 || end < mid || strings.Contains(rest[mid+2:end], " ") {
+				sb.WriteString("[[")
+				continue
+			}
+			sb.WriteString("[link=" + rest[mid+2:end] + "]")
+			sb.WriteString(markdownInline(rest[1:mid]))
+			sb.WriteString("[/]")
+			i += len([]rune(rest[:end]))
+		case (c == '*' || c == '_') && i+1 < len(r) && r[i+1] == c:
+			if c == '_' && !bold && isWord(i-1) {
+				sb.WriteString("__")
+				i++
+				continue
+			}
+			if bold {
+				sb.WriteString("[/b]")
+			} else {
+				sb.WriteString("[b]")
+			}
+			bold = !bold
+			i++
+		case c == '*' || c == '_':
+			// underscores inside of words like snake_case are no emphasis
+			if c == '_' && ((!italic && isWord(i-1)) || (italic && isWord(i+1))) {
+				sb.WriteRune(c)
+				continue
+			}
+			if italic {
+				sb.WriteString("[/i]")
+			} else {
+				sb.WriteString("[i]")
+			}
+			italic = !italic
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	return sb.String()
+}
+
+// appendSpan adds the text to the spans and merges it with the
+// last span if both have the same style
+func appendSpan(spans []richSpan, text string, sp richSpan) []richSpan {
+	if n := len(spans); n > 0 && spans[n-1].style == sp.style && spans[n-1].link == sp.link {
+		spans[n-1].text += text
+		return spans
+	}
+	sp.text = text
+	return append(spans, sp)
+}
+
+// trimSpans removes the trailing spaces of a line
+func trimSpans(spans []richSpan) []richSpan {
+	for len(spans) > 0 {
+		last := &spans[len(spans)-1]
+		last.text = strings.TrimRight(last.text, " ")
+		if last.text != "" {
+			break
+		}
+		spans = spans[:len(spans)-1]
+	}
+	return spans
+}
+
+// wrapSpans breaks the spans into lines of at most width characters
+// keeping the style of every word
+func wrapSpans(spans []richSpan, width int) [][]richSpan {
+	if width < 1 {
+		width = 1
+	}
+	ret := make([][]richSpan, 0)
+	line := make([]richSpan, 0)
+	l := 0
+	for _, sp := range spans {
+		for _, w := range splitWords(sp.text) {
+			if w == " " {
+				if l > 0 && l < width {
+					line = appendSpan(line, w, sp)
+					l++
+				}
+				continue
+			}
+			for w != "" {
+				wl := internalLen(w)
+				if l > 0 && l+wl > width {
+					ret = append(ret, trimSpans(line))
+					line = make([]richSpan, 0)
+					l = 0
+				}
+				part := w
+				if wl > width {
+					part, _ = splitWidthAtLeast(w, width)
+				}
+				line = appendSpan(line, part, sp)
+				l += internalLen(part)
+				w = w[len(part):]
+			}
+		}
+	}
+	if len(line) > 0 || len(ret) == 0 {
+		ret = append(ret, trimSpans(line))
+	}
+	return ret
+}
+
+// splitWords splits the text into words and single spaces
+func splitWords(s string) []string {
+	ret := make([]string, 0)
+	for i, w := range strings.Split(s, " ") {
+		if i > 0 {
+			ret = append(ret, " ")
+		}
+		if w != "" {
+			ret = append(ret, w)
+		}
+	}
+	return ret
+}
+
+// splitTableLine returns the trimmed cells of a markdown table line
+func splitTableLine(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	line = strings.TrimSuffix(line, "|")
+	ret := strings.Split(line, "|")
+	for i := range ret {
+		ret[i] = strings.TrimSpace(ret[i])
+	}
+	return ret
+}
+
+// markdownTable converts the lines of a markdown table. The alignment
+// of the columns is taken from the separator line
+func markdownTable(lines []string) *table.Table {
+	headers := splitTableLine(lines[0])
+	tbl := table.New().Headers(headers...)
+	aligns := make([]table.TextAlign, len(headers))
+	start := 1
+	if len(lines) > 1 {
+		sep := splitTableLine(lines[1])
+		isSep := true
+		for _, s := range sep {
+			if !mdTableSep.MatchString(s) {
+				isSep = false
+			}
+		}
+		if isSep {
+			start = 2
+			for i := 0; i < len(sep) && i < len(aligns); i++ {
+				s := sep[i]
+				if strings.HasSuffix(s, ":") && strings.HasPrefix(s, ":") {
+					aligns[i] = table.AlignCenter
+				} else if strings.HasSuffix(s, ":") {
+					aligns[i] = table.AlignRight
+				}
+			}
+		}
+	}
+	for _, l := range lines[start:] {
+		cells := splitTableLine(l)
+		row := tbl.CreateRow()
+		for i := range headers {
+			txt := ""
+			if i < len(cells) {
+				txt = cells[i]
+			}
+			row.AddDefaultText(txt)
+			row.Cells[i].Alignment = aligns[i]
+		}
+	}
+	return tbl
+}
+
+// markdownBlock writes a paragraph wrapped to the width. Every line
+// starts with prefix and all following lines with indent. Returns the
+// target of the link that was clicked
+func (g *GUI) markdownBlock(text, prefix, indent string, prefixStyle, style int, width int) string {
+	ret := ""
+	spans := parseRich(markdownInline(text), g.buffer.theme)
+	for i, l := range wrapSpans(spans, width-internalLen(prefix)) {
+		p := prefix
+		if i > 0 {
+			p = indent
+		}
+		if p != "" {
+			g.buffer.Write(p, prefixStyle, true)
+		}
+		if link := g.writeSpans(l, style); link != "" {
+			ret = link
+		}
+		g.buffer.Write("", 0, false)
+	}
+	return ret
+}
+
+// Markdown renders headings, emphasis, lists, code blocks, block quotes
+// and tables wrapped to the width of the current cell. Returns the target
+// of the link that was clicked or an empty string
+func (g *GUI) Markdown(src string) string {
+	g.buffer.PushID("MARKDOWN")
+	width := g.buffer.AvailableWidth()
+	lines := strings.Split(strings.ReplaceAll(src, "\r\n", "\n"), "\n")
+	// blank lines are only written between blocks
+	blank := false
+	written := false
+	ret := ""
+	paragraph := func(text, prefix, indent string, prefixStyle, style int) {
+		if link := g.markdownBlock(text, prefix, indent, prefixStyle, style, width); link != "" {
+			ret = link
+		}
+	}
+	block := func() {
+		if blank && written {
+			g.buffer.Write("", 0, false)
+		}
+		blank = false
+		written = true
+	}
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case strings.TrimSpace(line) == "":
+			blank = true
+		case mdFence.MatchString(line):
+			block()
+			fence := mdFence.FindStringSubmatch(line)[1]
+			code := make([]string, 0)
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), fence); i++ {
+				code = append(code, strings.ReplaceAll(lines[i], "\t", "    "))
+			}
+			cw := min(findMaxLen(code)+2, width)
+			for _, c := range code {
+				g.buffer.Write(formatString(truncate(" "+c, cw), cw, table.AlignLeft), ROLE_CODE, false)
+			}
+		case mdHeading.MatchString(line):
+			block()
+			m := mdHeading.FindStringSubmatch(line)
+			paragraph(m[2], "", "", 0, ROLE_HEADER)
+			if len(m[1]) == 1 {
+				g.buffer.Write(strings.Repeat("─", min(internalLen(m[2]), width)), ROLE_BORDER, false)
+			}
+		case mdRule.MatchString(line):
+			block()
+			g.buffer.Separator()
+		case mdTableLine.MatchString(line):
+			block()
+			rows := make([]string, 0)
+			for ; i < len(lines) && mdTableLine.MatchString(lines[i]); i++ {
+				rows = append(rows, lines[i])
+			}
+			i--
+			g.Table(markdownTable(rows))
+		case mdQuote.MatchString(line):
+			block()
+			text := make([]string, 0)
+			for ; i < len(lines) && mdQuote.MatchString(lines[i]); i++ {
+				text = append(text, mdQuote.FindStringSubmatch(lines[i])[1])
+			}
+			i--
+			paragraph(strings.Join(text, " "), "│ ", "│ ", ROLE_BORDER, ROLE_INFO)
+		case mdList.MatchString(line):
+			block()
+			m := mdList.FindStringSubmatch(line)
+			text := m[3]
+			// continuation lines belong to the item
+			for i+1 < len(lines) && strings.HasPrefix(lines[i+1], "  ") && !mdList.MatchString(lines[i+1]) && strings.TrimSpace(lines[i+1]) != "" {
+				i++
+				text += " " + strings.TrimSpace(lines[i])
+			}
+			bullet := "• "
+			if m[2] != "-" && m[2] != "*" && m[2] != "+" {
+				bullet = m[2] + " "
+			}
+			level := strings.Repeat(" ", len(strings.ReplaceAll(m[1], "\t", "    "))/2*INDENT_SIZE)
+			paragraph(text, level+bullet, strings.Repeat(" ", internalLen(level+bullet)), 0, 0)
+		default:
+			block()
+			text := strings.TrimSpace(line)
+			for i+1 < len(lines) && isParagraph(lines[i+1]) {
+				i++
+				text += " " + strings.TrimSpace(lines[i])
+			}
+			paragraph(text, "", "", 0, 0)
+		}
+	}
+	g.buffer.PopID()
+	return ret
+}
+
+// isParagraph returns true if the line continues a paragraph
+func isParagraph(line string) bool {
+	if strings.TrimSpace(line) == "" {
+		return false
+	}
+	for _, re := range []*regexp.Regexp{mdFence, mdHeading, mdRule, mdTableLine, mdQuote, mdList} {
+		if re.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
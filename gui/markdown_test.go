@@ -0,0 +1,58 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/amecky/table/table"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestMarkdownInline(t *testing.T) {
+	assert.Equal(t, "a [b]bold[/b] and [i]it[/i] snake_case", markdownInline("a **bold** and _it_ snake_case"))
+	assert.Equal(t, "[code]x[[0][/] [link=http://x.io]site[/]", markdownInline("`x[0]` [site](http://x.io)"))
+}
+
+func TestWrapSpans(t *testing.T) {
+	lines := wrapSpans(parseRich("one [b]two three[/] four", DarkTheme()), 10)
+	assert.Equal(t, 2, len(lines))
+	assert.Equal(t, "one ", lines[0][0].text)
+	assert.Equal(t, "two", lines[0][1].text)
+	assert.Equal(t, "three", lines[1][0].text)
+	assert.Equal(t, " four", lines[1][1].text)
+	assert.Equal(t, "def", wrapSpans(parseRich("abcdef", DarkTheme()), 3)[1][0].text)
+}
+
+func TestMarkdownTable(t *testing.T) {
+	tbl := markdownTable([]string{"| A | B |", "|---|--:|", "| 1 | 2 |", "| 3 |"})
+	assert.Equal(t, 2, len(tbl.TableHeaders))
+	assert.Equal(t, 2, len(tbl.Rows))
+	assert.Equal(t, table.AlignRight, tbl.Rows[0].Cells[1].Alignment)
+	assert.Equal(t, "", tbl.Rows[1].Cells[1].Text)
+}
+
+func TestMarkdown(t *testing.T) {
+	gui := NewGUI(40, 20)
+	gui.Begin()
+	gui.Markdown("# Title\n\nSome *text* which\nis wrapped.\n\n- one\n- two\n\n> quote\n\n```\ncode\n```")
+	gui.End()
+	lines := []string{"Title", "─────", "", "Some text which is wrapped.", "", "• one", "• two", "", "│ quote", "", " code"}
+	for i, l := range lines {
+		for j, ch := range []rune(l) {
+			r, _ := gui.buffer.At(1+j, 1+i)
+			assert.Equal(t, ch, r, "line %d", i)
+		}
+	}
+}
+
+func TestMarkdownLink(t *testing.T) {
+	gui := NewGUI(40, 10)
+	src := "See [the docs](http://x.io) for more"
+	gui.Begin()
+	assert.Equal(t, "", gui.Markdown(src))
+	gui.End()
+	gui.Begin()
+	gui.SetMouseEvent(tea.MouseEvent{X: 6, Y: 1, Button: tea.MouseButtonLeft})
+	assert.Equal(t, "http://x.io", gui.Markdown(src))
+	gui.End()
+}
@@ -0,0 +1,75 @@
+package imgui
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MarkerConverter maps the marker and the text of a table cell to a style
+type MarkerConverter func(marker int, text string) int
+
+var PALETTE_DIVERGING = Palette{"#a21a1a", WHITE, "#389a1d"}
+var PALETTE_SEQUENTIAL = Palette{"#81858d", "#1a7091", "#34e2e2"}
+
+// DefaultMarkerConverter maps -1 to red, 1 to light green and any other
+// marker to the table styles relative to ROLE_DANGER
+func DefaultMarkerConverter(marker int, text string) int {
+	if marker == 0 {
+		return 0
+	}
+	if marker == -1 {
+		return ROLE_DANGER
+	}
+	if marker == 1 {
+		return ROLE_SUCCESS_LIGHT
+	}
+	return ROLE_DANGER - 2 + marker
+}
+
+// DivergingScale colors cells by their numeric value. Values between min and
+// zero fade from red to neutral and values between zero and max from neutral
+// to green. Cells without a numeric value use the DefaultMarkerConverter
+func DivergingScale(min, max float64) MarkerConverter {
+	return func(marker int, text string) int {
+		v, ok := parseCellValue(text)
+		if !ok {
+			return DefaultMarkerConverter(marker, text)
+		}
+		t := 0.5
+		if v < 0 && min < 0 {
+			t = 0.5 - 0.5*v/min
+		} else if v > 0 && max > 0 {
+			t = 0.5 + 0.5*v/max
+		}
+		return AddStyle(Style{foreground: PALETTE_DIVERGING.step(t), flags: ATTR_FOREGROUND | ATTR_BOLD})
+	}
+}
+
+// SequentialScale colors cells by the position of their numeric value
+// between min and max using the palette
+func SequentialScale(min, max float64, palette Palette) MarkerConverter {
+	return func(marker int, text string) int {
+		v, ok := parseCellValue(text)
+		if !ok {
+			return DefaultMarkerConverter(marker, text)
+		}
+		return AddStyle(Style{foreground: palette.step(normalize(v, min, max)), flags: ATTR_FOREGROUND | ATTR_BOLD})
+	}
+}
+
+// parseCellValue extracts a number from cell texts like "12.5", "+3.2%" or "1,234".
+// Texts like "NaN" or "Inf" are not a value
+func parseCellValue(text string) (float64, bool) {
+	t := strings.TrimSpace(text)
+	t = strings.TrimSuffix(t, "%")
+	t = strings.TrimPrefix(t, "+")
+	t = strings.ReplaceAll(t, ",", "")
+	if t == "" {
+		return 0, false
+	}
+	v, err := strconv.ParseFloat(t, 64)
+	if err != nil || !finite(v) {
+		return 0, false
+	}
+	return v, true
+}
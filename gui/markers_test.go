@@ -0,0 +1,50 @@
+package imgui
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestParseCellValue(t *testing.T) {
+	v, ok := parseCellValue(" +3.5% ")
+	assert.True(t, ok)
+	assert.Equal(t, 3.5, v)
+
+	v, ok = parseCellValue("1,234")
+	assert.True(t, ok)
+	assert.Equal(t, 1234.0, v)
+
+	_, ok = parseCellValue("TESLA")
+	assert.False(t, ok)
+
+	for _, txt := range []string{"NaN", "nan", "Inf", "-inf"} {
+		_, ok = parseCellValue(txt)
+		assert.False(t, ok)
+	}
+}
+
+func TestSequentialScaleNonFinite(t *testing.T) {
+	conv := SequentialScale(0, 10, PALETTE_SEQUENTIAL)
+	assert.Equal(t, TABLE_RED, conv(-1, "NaN"))
+	assert.Equal(t, 0, conv(0, "Inf"))
+}
+
+func TestScaleStyles(t *testing.T) {
+	conv := DivergingScale(-10, 10)
+	conv(0, "1")
+	n := len(customStyles.styles)
+	for i := 0; i < 1000; i++ {
+		conv(0, strconv.FormatFloat(float64(i)/100, 'f', 2, 64))
+	}
+	assert.True(t, len(customStyles.styles)-n <= PALETTE_STEPS+1)
+}
+
+func TestDivergingScale(t *testing.T) {
+	conv := DivergingScale(-10, 10)
+	assert.Equal(t, Hex("#a21a1a"), customStyle(conv(0, "-10")).foreground)
+	assert.Equal(t, Hex(WHITE), customStyle(conv(0, "0")).foreground)
+	assert.Equal(t, Hex("#389a1d"), customStyle(conv(0, "10")).foreground)
+	assert.Equal(t, TABLE_RED, conv(-1, "n/a"))
+}
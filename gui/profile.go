@@ -0,0 +1,101 @@
+package imgui
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// ColorProfile is the number of colors the terminal can display
+type ColorProfile int32
+
+const (
+	PROFILE_TRUECOLOR ColorProfile = iota
+	PROFILE_ANSI256
+	PROFILE_ANSI16
+	PROFILE_MONO
+)
+
+// the profile used by Style.Convert or -1 if it is not detected yet
+var colorProfile atomic.Int32
+
+func init() {
+	colorProfile.Store(-1)
+}
+
+// DetectColorProfile derives the profile from the environment. NO_COLOR
+// disables colors, COLORTERM=truecolor or 24bit enables 24 bit colors
+// and otherwise TERM is checked for 256 color support
+func DetectColorProfile() ColorProfile {
+	if os.Getenv("NO_COLOR") != "" {
+		return PROFILE_MONO
+	}
+	ct := strings.ToLower(os.Getenv("COLORTERM"))
+	if ct == "truecolor" || ct == "24bit" {
+		return PROFILE_TRUECOLOR
+	}
+	term := strings.ToLower(os.Getenv("TERM"))
+	switch {
+	case term == "dumb":
+		return PROFILE_MONO
+	case strings.Contains(term, "truecolor") || strings.Contains(term, "direct"):
+		return PROFILE_TRUECOLOR
+	case strings.Contains(term, "256color"):
+		return PROFILE_ANSI256
+	}
+	return PROFILE_ANSI16
+}
+
+// SetColorProfile overrides the detected profile
+func SetColorProfile(p ColorProfile) {
+	colorProfile.Store(int32(p))
+}
+
+// CurrentColorProfile returns the profile used to convert styles. It is
+// detected on first use unless it was set with SetColorProfile
+func CurrentColorProfile() ColorProfile {
+	p := colorProfile.Load()
+	if p < 0 {
+		p = int32(DetectColorProfile())
+		colorProfile.CompareAndSwap(-1, p)
+	}
+	return ColorProfile(p)
+}
+
+var nearestColors sync.Map
+
+type nearestKey struct {
+	c     Color
+	count int
+}
+
+// nearestAnsi returns the index of the closest color in ANSI_COLORS. With 16
+// colors only the first 16 entries are used. Otherwise they are skipped
+// since most terminals let the user change them
+func nearestAnsi(c Color, count int) int {
+	key := nearestKey{c, count}
+	if idx, ok := nearestColors.Load(key); ok {
+		return idx.(int)
+	}
+	start := 16
+	if count == 16 {
+		start = 0
+	}
+	best := start
+	bestDist := -1
+	for i := start; i < count; i++ {
+		a := Hex(ANSI_COLORS[i])
+		dr := int(a.r) - int(c.r)
+		dg := int(a.g) - int(c.g)
+		db := int(a.b) - int(c.b)
+		// green is weighted the most like the eye does
+		d := 3*dr*dr + 4*dg*dg + 2*db*db
+		if bestDist == -1 || d < bestDist {
+			best = i
+			bestDist = d
+		}
+	}
+	nearestColors.Store(key, best)
+	return best
+}
@@ -0,0 +1,38 @@
+package imgui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestDetectColorProfile(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("COLORTERM", "truecolor")
+	t.Setenv("TERM", "xterm-256color")
+	assert.Equal(t, PROFILE_TRUECOLOR, DetectColorProfile())
+	t.Setenv("COLORTERM", "")
+	assert.Equal(t, PROFILE_ANSI256, DetectColorProfile())
+	t.Setenv("TERM", "linux")
+	assert.Equal(t, PROFILE_ANSI16, DetectColorProfile())
+	t.Setenv("TERM", "dumb")
+	assert.Equal(t, PROFILE_MONO, DetectColorProfile())
+	t.Setenv("TERM", "xterm-256color")
+	t.Setenv("NO_COLOR", "1")
+	assert.Equal(t, PROFILE_MONO, DetectColorProfile())
+}
+
+func TestConvertProfiles(t *testing.T) {
+	defer SetColorProfile(CurrentColorProfile())
+	s := NewStyle("#ff0000", "#000080", true)
+	SetColorProfile(PROFILE_TRUECOLOR)
+	assert.True(t, strings.HasPrefix(s.Convert("x"), "\x1b[1;38;2;255;0;0;48;2;0;0;128mx"))
+	SetColorProfile(PROFILE_ANSI256)
+	assert.True(t, strings.HasPrefix(s.Convert("x"), "\x1b[1;38;5;196;48;5;18mx"))
+	SetColorProfile(PROFILE_ANSI16)
+	assert.True(t, strings.HasPrefix(s.Convert("x"), "\x1b[1;91;44mx"))
+	SetColorProfile(PROFILE_MONO)
+	assert.True(t, strings.HasPrefix(s.Convert("x"), "\x1b[1;7mx"))
+	assert.Equal(t, "x", NewStyle("#ff0000", "", false).Convert("x"))
+}
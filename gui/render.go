@@ -0,0 +1,105 @@
+package imgui
+
+import "strconv"
+
+// DIFF_GAP is the default number of unchanged cells which are written
+// again instead of moving the cursor to the next changed cell
+const DIFF_GAP = 4
+
+// FrameStats describes the output of the last frame
+type FrameStats struct {
+	// the number of cells written
+	Cells int
+	// the number of cursor moves or lines written
+	Spans int
+	// the size of the output
+	Bytes int
+	// true if the whole screen was written
+	Full bool
+}
+
+func (b *Buffer) changed(idx int) bool {
+	return b.chars[idx] != b.prevChars[idx] || b.styles[idx] != b.prevStyles[idx] || b.clusters[idx] != b.prevClusters[idx]
+}
+
+// Diff draws the frame and returns only the cells which changed since the
+// last call as cursor moves followed by the text. The first frame and
+// frames after Invalidate contain the whole screen
+func (b *Buffer) Diff() string {
+	b.draw()
+	b.prepareStyles()
+	b.out.Reset()
+	full := len(b.prevChars) != b.size
+	stats := FrameStats{Full: full}
+	for y := 0; y < b.height-1; y++ {
+		cy := y * b.width
+		x := 0
+		for x < b.width {
+			if !full && !b.changed(cy+x) {
+				x++
+				continue
+			}
+			// extend the span until more than diffGap cells are unchanged
+			start := x
+			end := x
+			// a double width character is always written completely
+			if start > 0 && b.chars[cy+start] == WIDE_CONTINUATION {
+				start--
+			}
+			for x < b.width && x-end <= b.diffGap {
+				if full || b.changed(cy+x) {
+					end = x + 1
+				}
+				x++
+			}
+			b.out.WriteString(CSI + strconv.Itoa(y+1) + ";" + strconv.Itoa(start+1) + "H")
+			b.writeCells(cy, start, end)
+			stats.Cells += end - start
+			stats.Spans++
+		}
+	}
+	if full {
+		b.prevChars = make([]rune, b.size)
+		b.prevStyles = make([]int, b.size)
+		b.prevClusters = make([]string, b.size)
+	}
+	copy(b.prevChars, b.chars)
+	copy(b.prevStyles, b.styles)
+	copy(b.prevClusters, b.clusters)
+	stats.Bytes = b.out.Len()
+	b.stats = stats
+	return b.out.String()
+}
+
+// Invalidate forces the next Diff to write the whole screen
+func (b *Buffer) Invalidate() {
+	b.prevChars = nil
+	b.prevStyles = nil
+	b.prevClusters = nil
+}
+
+// EndDiff works like End but returns only the changes since the last
+// frame. The output is meant for standalone renderers which write it
+// directly to the terminal. Run uses End because bubbletea expects the
+// whole screen from View
+func (g *GUI) EndDiff() string {
+	g.finish()
+	return g.buffer.Diff()
+}
+
+// SetDiffGap sets the number of unchanged cells EndDiff writes again
+// instead of moving the cursor
+func (g *GUI) SetDiffGap(gap int) {
+	g.buffer.diffGap = max(gap, 0)
+}
+
+// Invalidate forces the next EndDiff to redraw the whole screen for
+// example after the terminal was resized or cleared
+func (g *GUI) Invalidate() {
+	g.buffer.Invalidate()
+}
+
+// Stats returns the statistics of the last frame
+func (g *GUI) Stats() FrameStats {
+	return g.buffer.stats
+}
@@ -0,0 +1,44 @@
+package imgui
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestDiff(t *testing.T) {
+	gui := NewGUI(20, 5)
+	frame := func(price float64) string {
+		gui.Begin()
+		gui.Text("Price")
+		gui.Text(fmt.Sprintf("%.2f", price))
+		return gui.EndDiff()
+	}
+	frame(12.5)
+	st := gui.Stats()
+	assert.True(t, st.Full)
+	assert.Equal(t, 20*4, st.Cells)
+
+	// nothing changed
+	assert.Equal(t, "", frame(12.5))
+	assert.Equal(t, 0, gui.Stats().Cells)
+
+	// only the changed digits are written
+	out := frame(12.7)
+	assert.Equal(t, CSI+"3;5H7", out)
+	assert.Equal(t, FrameStats{Cells: 1, Spans: 1, Bytes: len(out)}, gui.Stats())
+
+	// changes close to each other are written as one span
+	out = frame(92.1)
+	assert.Equal(t, CSI+"3;2H92.1", out)
+
+	gui.Invalidate()
+	frame(92.1)
+	assert.True(t, gui.Stats().Full)
+	assert.Equal(t, "", frame(92.1))
+
+	// without a gap every change is written as own span
+	gui.SetDiffGap(0)
+	assert.Equal(t, CSI+"3;2H1"+CSI+"3;5H7", frame(12.7))
+}
@@ -0,0 +1,224 @@
+package imgui
+
+import (
+	"strings"
+)
+
+// COLOR_NAMES are the colors which can be used by name in TextRich
+var COLOR_NAMES = map[string]string{
+	"black":         BLACK,
+	"red":           RED,
+	"green":         GREEN,
+	"yellow":        YELLOW,
+	"blue":          BLUE,
+	"purple":        PURPLE,
+	"cyan":          CYAN,
+	"white":         WHITE,
+	"gray":          GRAY,
+	"bright_black":  BRIGHT_BLACK,
+	"bright_red":    BRIGHT_RED,
+	"bright_green":  BRIGHT_GREEN,
+	"bright_yellow": BRIGHT_YELLOW,
+	"bright_blue":   BRIGHT_BLUE,
+	"bright_purple": BRIGHT_PURPLE,
+	"bright_cyan":   BRIGHT_CYAN,
+	"bright_white":  BRIGHT_WHITE,
+}
+
+// richSpan is a part of a rich text with the same style
+type richSpan struct {
+	text  string
+	style Style
+	link  string
+}
+
+// richTag is an open tag. A tag can contain several attributes
+// separated by spaces like [bold red]
+type richTag struct {
+	name  string
+	style Style
+	link  string
+}
+
+// parseColor returns the hex value of a color name or hex string
+// like #fff or #ffffff
+func parseColor(s string) (string, bool) {
+	if strings.HasPrefix(s, "#") {
+		return expandHex(s)
+	}
+	c, ok := COLOR_NAMES[s]
+	return c, ok
+}
+
+// withColors sets the colors of the role which are defined
+func withColors(s, role Style) Style {
+	if role.Has(ATTR_FOREGROUND) {
+		s.foreground = role.foreground
+		s.flags |= ATTR_FOREGROUND
+	}
+	if role.Has(ATTR_BACKGROUND) {
+		s.background = role.background
+		s.flags |= ATTR_BACKGROUND
+	}
+	return s
+}
+
+// applyTag applies all attributes of the tag to the style. Code and
+// links take their colors from the theme. Returns false if the tag
+// contains an unknown attribute
+func applyTag(tag string, s Style, t *Theme) (Style, string, bool) {
+	link := ""
+	for _, a := range strings.Fields(tag) {
+		switch {
+		case a == "bold" || a == "b":
+			s = s.Bold()
+		case a == "italic" || a == "i":
+			s = s.Italic()
+		case a == "underline" || a == "u":
+			s = s.Underline()
+		case a == "dim":
+			s = s.Dim()
+		case a == "blink":
+			s = s.Blink()
+		case a == "reverse":
+			s = s.Reverse()
+		case a == "strike" || a == "s":
+			s = s.Strikethrough()
+		case a == "overline":
+			s = s.Overline()
+		case a == "code":
+			s = withColors(s, t.Code)
+		case strings.HasPrefix(a, "bg="):
+			c, ok := parseColor(a[3:])
+			if !ok {
+				return s, "", false
+			}
+			s = s.Background(c)
+		case strings.HasPrefix(a, "link="):
+			link = a[5:]
+			if !s.Has(ATTR_FOREGROUND) && t.Accent.Has(ATTR_FOREGROUND) {
+				s.foreground = t.Accent.foreground
+				s.flags |= ATTR_FOREGROUND
+			}
+			s = s.Underline()
+		default:
+			c, ok := parseColor(a)
+			if !ok {
+				return s, "", false
+			}
+			s = s.Foreground(c)
+		}
+	}
+	return s, link, true
+}
+
+// parseRich splits the markup into spans. Tags are written in brackets
+// and closed by [/] or [/tag]. Unknown tags are kept as text and [[
+// writes a single bracket
+func parseRich(src string, t *Theme) []richSpan {
+	ret := make([]richSpan, 0)
+	stack := make([]richTag, 0)
+	current := func() (Style, string) {
+		if len(stack) == 0 {
+			return Style{}, ""
+		}
+		t := stack[len(stack)-1]
+		return t.style, t.link
+	}
+	var sb strings.Builder
+	flush := func() {
+		if sb.Len() == 0 {
+			return
+		}
+		s, l := current()
+		ret = append(ret, richSpan{text: sb.String(), style: s, link: l})
+		sb.Reset()
+	}
+	for i := 0; i < len(src); i++ {
+		if src[i] != '[' {
+			sb.WriteByte(src[i])
+			continue
+		}
+		if i+1 < len(src) && src[i+1] == '[' {
+			sb.WriteByte('[')
+			i++
+			continue
+		}
+		end := strings.IndexByte(src[i:], ']')
+		if end == -1 {
+			sb.WriteString(src[i:])
+			break
+		}
+		tag := src[i+1 : i+end]
+		if strings.HasPrefix(tag, "/") {
+			flush()
+			name := tag[1:]
+			idx := len(stack) - 1
+			if name != "" {
+				for idx >= 0 && stack[idx].name != name {
+					idx--
+				}
+			}
+			if idx >= 0 {
+				stack = stack[:idx]
+			}
+			i += end
+			continue
+		}
+		s, l := current()
+		ns, nl, ok := applyTag(tag, s, t)
+		if !ok {
+			sb.WriteString(src[i : i+end+1])
+			i += end
+			continue
+		}
+		flush()
+		if nl == "" {
+			nl = l
+		}
+		stack = append(stack, richTag{name: tag, style: ns, link: nl})
+		i += end
+	}
+	flush()
+	return ret
+}
+
+// writeSpans writes the spans in the current line. Spans without
+// a style are written with def. Returns the target of the clicked link
+func (g *GUI) writeSpans(spans []richSpan, def int) string {
+	ret := ""
+	for _, sp := range spans {
+		style := def
+		if sp.style.flags != 0 {
+			style = AddStyle(sp.style)
+		}
+		pos := g.buffer.CurrentPos()
+		g.buffer.Write(sp.text, style, true)
+		if sp.link != "" && g.processed == 1 {
+			r := rect{x: pos.x, y: pos.y, w: internalLen(sp.text) - 1}
+			if r.Inside(g.mouseEvent.X, g.mouseEvent.Y) {
+				g.processed = -1
+				ret = sp.link
+			}
+		}
+	}
+	return ret
+}
+
+// TextRich writes a text with inline markup like "[bold]Price[/] [red]-2.3%[/]".
+// Supported are bold, italic, underline, dim, blink, reverse, strike,
+// overline, code, colors by name or hex value,
+// background colors with bg=color and links with link=target. Returns
+// the target of the link that was clicked or an empty string
+func (g *GUI) TextRich(src string) string {
+	g.buffer.PushID("RICH_" + src)
+	ret := ""
+	for _, line := range strings.Split(src, "\n") {
+		if l := g.writeSpans(parseRich(line, g.buffer.theme), 0); l != "" {
+			ret = l
+		}
+		g.buffer.Write("", 0, false)
+	}
+	g.buffer.PopID()
+	return ret
+}
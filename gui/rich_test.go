@@ -0,0 +1,57 @@
+package imgui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestParseRich(t *testing.T) {
+	spans := parseRich("[bold]Price[/] [red]-2.3%[/] [[x] [nope]", DarkTheme())
+	assert.Equal(t, 4, len(spans))
+	assert.Equal(t, "Price", spans[0].text)
+	assert.Equal(t, ATTR_BOLD, spans[0].style.flags)
+	assert.Equal(t, " ", spans[1].text)
+	assert.Equal(t, uint16(0), spans[1].style.flags)
+	assert.Equal(t, "-2.3%", spans[2].text)
+	assert.Equal(t, Hex(RED), spans[2].style.foreground)
+	assert.Equal(t, " [x] [nope]", spans[3].text)
+}
+
+func TestParseRichNested(t *testing.T) {
+	spans := parseRich("[i]a[bold bg=#fff]b[/i]c", DarkTheme())
+	assert.Equal(t, 3, len(spans))
+	assert.Equal(t, ATTR_ITALIC, spans[0].style.flags)
+	assert.Equal(t, ATTR_ITALIC|ATTR_BOLD|ATTR_BACKGROUND, spans[1].style.flags)
+	assert.Equal(t, Hex("#ffffff"), spans[1].style.background)
+	// closing the italic tag closes the inner tag as well
+	assert.Equal(t, uint16(0), spans[2].style.flags)
+	assert.True(t, strings.HasPrefix(Style{flags: ATTR_ITALIC | ATTR_UNDERLINE}.Convert("x"), "\x1b[3;4mx"))
+}
+
+func TestParseRichInvalidColor(t *testing.T) {
+	spans := parseRich("[fg=#zzzzzz]a [#zzzzzz]b [bg=#zzzzzz]c [bg=#12]d [#0a0]e", DarkTheme())
+	assert.Equal(t, 2, len(spans))
+	assert.Equal(t, "[fg=#zzzzzz]a [#zzzzzz]b [bg=#zzzzzz]c [bg=#12]d ", spans[0].text)
+	assert.Equal(t, uint16(0), spans[0].style.flags)
+	assert.Equal(t, Hex("#00aa00"), spans[1].style.foreground)
+}
+
+func TestTextRichLink(t *testing.T) {
+	gui := NewGUI(40, 10)
+	gui.SetMouseEvent(tea.MouseEvent{X: 9, Y: 1, Button: tea.MouseButtonLeft})
+	gui.Begin()
+	ret := gui.TextRich("see [link=docs]the docs[/] here")
+	gui.End()
+	assert.Equal(t, "docs", ret)
+	r, _ := gui.buffer.At(5, 1)
+	assert.Equal(t, 't', r)
+
+	gui.SetMouseEvent(tea.MouseEvent{X: 14, Y: 1, Button: tea.MouseButtonLeft})
+	gui.Begin()
+	ret = gui.TextRich("see [link=docs]the docs[/] here")
+	gui.End()
+	assert.Equal(t, "", ret)
+}
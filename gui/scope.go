@@ -0,0 +1,185 @@
+package imgui
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const (
+	SCOPE_ROW     = "row"
+	SCOPE_CELL    = "cell"
+	SCOPE_GROUP   = "group"
+	SCOPE_MENUBAR = "menu bar"
+	SCOPE_MENU    = "menu"
+	SCOPE_ID      = "id"
+	SCOPE_SPLIT   = "split"
+	SCOPE_FORM    = "form"
+	SCOPE_CHILD   = "child"
+)
+
+// scope is an open Start*/Begin* call. The caller is only
+// recorded in debug mode
+type scope struct {
+	kind     string
+	caller   string
+	implicit bool
+}
+
+// SetDebug enables recording the call site of every Start*/Begin* call.
+// Unbalanced calls are then reported with file and line and are shown
+// at the bottom of the screen
+func (g *GUI) SetDebug(debug bool) {
+	g.debug = debug
+}
+
+// Err returns the unbalanced layout calls of the last frame or nil
+func (g *GUI) Err() error {
+	return g.err
+}
+
+// caller returns file:line of the first caller outside of the GUI and Buffer methods
+func (g *GUI) caller() string {
+	if !g.debug {
+		return ""
+	}
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		f, more := frames.Next()
+		if !strings.Contains(f.Function, ".(*GUI).") && !strings.Contains(f.Function, ".(*Buffer).") {
+			return fmt.Sprintf("%s:%d", filepath.Base(f.File), f.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func (g *GUI) pushScope(kind string) {
+	g.scopes = append(g.scopes, scope{kind: kind, caller: g.caller()})
+}
+
+// popScope closes the innermost scope of the kind. All scopes opened after
+// it are closed as well and reported
+func (g *GUI) popScope(kind string) {
+	idx := -1
+	for i := len(g.scopes) - 1; i >= 0; i-- {
+		if g.scopes[i].kind == kind {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		g.problems = append(g.problems, "end of "+kind+" without start"+at(g.caller()))
+		return
+	}
+	end := g.caller()
+	for len(g.scopes) > idx+1 {
+		s := g.scopes[len(g.scopes)-1]
+		g.problems = append(g.problems, s.kind+" started"+at(s.caller)+" not closed before end of "+kind+at(end))
+		g.closeScope(s)
+	}
+	g.closeScope(g.scopes[idx])
+}
+
+// closeScope removes the scope from the stack and runs the matching End* logic
+func (g *GUI) closeScope(s scope) {
+	g.scopes = g.scopes[:len(g.scopes)-1]
+	switch s.kind {
+	case SCOPE_ROW:
+		g.buffer.EndRow()
+	case SCOPE_CELL:
+		g.buffer.EndCell()
+	case SCOPE_GROUP:
+		g.endGroup()
+	case SCOPE_MENU:
+		g.endMenu()
+	case SCOPE_ID:
+		g.buffer.uids.Pop()
+	case SCOPE_SPLIT:
+		g.endSplit()
+	case SCOPE_FORM:
+		g.endForm()
+	case SCOPE_CHILD:
+		g.buffer.EndChild()
+	}
+}
+
+// closeAll closes every open scope at the end of the frame. The
+// implicit row and cell created by Begin are not reported
+func (g *GUI) closeAll() {
+	for len(g.scopes) > 0 {
+		s := g.scopes[len(g.scopes)-1]
+		if !s.implicit {
+			g.problems = append(g.problems, s.kind+" started"+at(s.caller)+" not closed")
+		}
+		g.closeScope(s)
+	}
+}
+
+// claimScope turns the implicit scope of the kind created by Begin
+// into a regular one
+func (g *GUI) claimScope(kind string) {
+	for i := range g.scopes {
+		if g.scopes[i].kind == kind && g.scopes[i].implicit {
+			g.scopes[i].implicit = false
+			g.scopes[i].caller = g.caller()
+			return
+		}
+	}
+}
+
+// dropImplicit removes the implicit row and cell created by Begin
+// when a widget takes them over
+func (g *GUI) dropImplicit() {
+	ret := g.scopes[:0]
+	for _, s := range g.scopes {
+		if !s.implicit {
+			ret = append(ret, s)
+		}
+	}
+	g.scopes = ret
+}
+
+func at(caller string) string {
+	if caller == "" {
+		return ""
+	}
+	return " at " + caller
+}
+
+// finishProblems converts the problems of the frame into the error and
+// draws them at the bottom of the screen in debug mode
+func (g *GUI) finishProblems() {
+	g.err = nil
+	if len(g.problems) == 0 {
+		return
+	}
+	g.err = errors.New(strings.Join(g.problems, "\n"))
+	if g.debug {
+		g.buffer.PushPopup(0, 0, g.width, g.height)
+		defer g.buffer.PopClip()
+		for i, p := range g.problems {
+			y := g.height - 2 - len(g.problems) + 1 + i
+			if y >= 0 {
+				g.buffer.WriteEx(0, y, p, ROLE_DANGER)
+			}
+		}
+	}
+	g.problems = g.problems[:0]
+}
+
+// PushID opens an ID scope. All widgets created until PopID get
+// unique ids even if they have the same label
+func (g *GUI) PushID(id string) {
+	g.buffer.uids.Push(id)
+	g.pushScope(SCOPE_ID)
+}
+
+func (g *GUI) PopID() {
+	g.popScope(SCOPE_ID)
+}
@@ -0,0 +1,65 @@
+package imgui
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestBalancedCalls(t *testing.T) {
+	gui := NewGUI(20, 6)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("Hello")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+	assert.NoError(t, gui.Err())
+
+	gui.Begin()
+	gui.Text("Implicit")
+	gui.End()
+	assert.NoError(t, gui.Err())
+}
+
+// previousLine returns file:line of the line before the caller
+func previousLine() string {
+	_, file, line, _ := runtime.Caller(1)
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line-1)
+}
+
+func TestUnbalancedCalls(t *testing.T) {
+	gui := NewGUI(60, 10)
+	gui.SetDebug(true)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCell()
+	outerCell := previousLine()
+	gui.Text("Hello")
+	gui.StartCell()
+	innerCell := previousLine()
+	gui.EndRow()
+	endRow := previousLine()
+	gui.StartRow()
+	row := previousLine()
+	gui.StartGroup()
+	group := previousLine()
+	gui.End()
+	err := gui.Err()
+	assert.Error(t, err)
+	lines := strings.Split(err.Error(), "\n")
+	assert.Equal(t, 4, len(lines))
+	assert.Equal(t, "cell started at "+innerCell+" not closed before end of row at "+endRow, lines[0])
+	assert.Equal(t, "cell started at "+outerCell+" not closed before end of row at "+endRow, lines[1])
+	assert.Equal(t, "group started at "+group+" not closed", lines[2])
+	assert.Equal(t, "row started at "+row+" not closed", lines[3])
+	// the problems are shown at the bottom of the screen
+	r, _ := gui.buffer.At(0, 5)
+	assert.Equal(t, 'c', r)
+	assert.Equal(t, 0, len(gui.scopes))
+}
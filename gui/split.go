@@ -0,0 +1,239 @@
+package imgui
+
+import "math"
+
+// SPLIT_MIN_SIZE is the default minimum size of a pane including the borders
+const SPLIT_MIN_SIZE = 6
+
+type SplitOptions struct {
+	// MinSize is the minimum size of a pane including the borders.
+	// If it is 0 SPLIT_MIN_SIZE is used
+	MinSize int
+}
+
+// splitState keeps the area and the pane that is currently active
+// of a split and the divider being dragged
+type splitState struct {
+	orientation Orientation
+	ratios      []*float64
+	minSize     int
+	sizes       []int
+	pane        int
+	drag        int
+	x           int
+	y           int
+	total       int
+	other       int
+}
+
+func (g *GUI) splitState(id string) *splitState {
+	st, ok := g.splits[id]
+	if !ok {
+		st = &splitState{drag: -1}
+		g.splits[id] = st
+	}
+	return st
+}
+
+// paneSizes converts the ratios into the outer size of every pane
+func paneSizes(ratios []*float64, total int) []int {
+	ret := make([]int, len(ratios)+1)
+	prev := 0
+	for i, r := range ratios {
+		b := int(math.Round(*r * float64(total)))
+		ret[i] = b - prev
+		prev = b
+	}
+	ret[len(ratios)] = total - prev
+	return ret
+}
+
+// clampRatio keeps the divider at least minSize away from the
+// neighbouring dividers
+func clampRatio(ratios []*float64, idx, total, minSize int) {
+	if total <= 0 {
+		return
+	}
+	mn := 0.0
+	if idx > 0 {
+		mn = *ratios[idx-1]
+	}
+	mx := 1.0
+	if idx < len(ratios)-1 {
+		mx = *ratios[idx+1]
+	}
+	d := float64(minSize) / float64(total)
+	*ratios[idx] = math.Max(mn+d, math.Min(mx-d, *ratios[idx]))
+}
+
+// BeginSplit divides the remaining area into panes separated by dividers
+// which can be dragged with the mouse. Every ratio is the position of a
+// divider as fraction of the area so n ratios create n+1 panes. The first
+// pane is started right away and NextPane moves to the next one
+func (g *GUI) BeginSplit(id string, orientation Orientation, ratios []*float64) {
+	g.BeginSplitEx(id, orientation, ratios, SplitOptions{})
+}
+
+func (g *GUI) BeginSplitEx(id string, orientation Orientation, ratios []*float64, opts SplitOptions) {
+	st := g.splitState(id)
+	st.orientation = orientation
+	st.ratios = ratios
+	st.minSize = opts.MinSize
+	if st.minSize <= 0 {
+		st.minSize = SPLIT_MIN_SIZE
+	}
+	st.pane = 0
+	// a split at the start of the frame replaces the row and cell created by Begin
+	reuse := g.started
+	if reuse {
+		g.started = false
+		g.dropImplicit()
+	} else {
+		g.buffer.StartRow()
+	}
+	g.pushScope(SCOPE_SPLIT)
+	g.splitStack = append(g.splitStack, st)
+	r := g.buffer.rows[g.buffer.openRows[len(g.buffer.openRows)-1]]
+	st.x = r.x
+	st.y = r.y
+	w := g.width - r.x
+	if r.parent != -1 && r.parent < len(g.buffer.resolved) {
+		pc := g.buffer.cells[r.parent]
+		w = g.buffer.resolved[r.parent] - 1 - (r.x - pc.x)
+	}
+	h := g.height - 1 - r.y
+	st.total = w
+	st.other = h
+	if orientation == VERTICAL {
+		st.total = h
+		st.other = w
+	}
+	g.handleSplitDrag(st)
+	st.sizes = paneSizes(ratios, st.total)
+	g.drawDividers(st)
+	if reuse {
+		g.buffer.cells[0].opts = st.paneOptions()
+	} else {
+		g.startPane(st)
+	}
+}
+
+// dividerRect returns the two columns or rows around the divider at pos
+func (st *splitState) dividerRect(pos int) rect {
+	if st.orientation == HORIZONTAL {
+		return rect{x: st.x + pos - 1, y: st.y, w: 1, h: st.other - 1}
+	}
+	return rect{x: st.x, y: st.y + pos - 1, w: st.other - 1, h: 1}
+}
+
+// handleSplitDrag starts dragging when a divider is pressed and moves
+// the divider with the mouse until the button is released
+func (g *GUI) handleSplitDrag(st *splitState) {
+	if !g.mouseDown {
+		st.drag = -1
+	}
+	// the mouse position is only updated by moves after the press
+	mx, my := g.mouseX, g.mouseY
+	pos := 0
+	for i, size := range paneSizes(st.ratios, st.total)[:len(st.ratios)] {
+		pos += size
+		if g.processed == 1 && st.dividerRect(pos).Inside(g.mouseEvent.X, g.mouseEvent.Y) {
+			st.drag = i
+			g.processed = -1
+			mx, my = g.mouseEvent.X, g.mouseEvent.Y
+		}
+	}
+	if st.drag >= 0 && st.drag < len(st.ratios) && st.total > 0 {
+		p := mx - st.x
+		if st.orientation == VERTICAL {
+			p = my - st.y
+		}
+		*st.ratios[st.drag] = float64(p) / float64(st.total)
+		clampRatio(st.ratios, st.drag, st.total, st.minSize)
+	}
+}
+
+// drawDividers highlights the hovered or dragged divider
+func (g *GUI) drawDividers(st *splitState) {
+	area := rect{x: st.x, y: st.y, w: st.total, h: st.other}
+	if st.orientation == VERTICAL {
+		area.w, area.h = st.other, st.total
+	}
+	// the dividers are drawn on top of the borders of the panes
+	g.buffer.PushPopup(area.x, area.y, area.w, area.h)
+	defer g.buffer.PopClip()
+	pos := 0
+	for i, size := range st.sizes[:len(st.ratios)] {
+		pos += size
+		r := st.dividerRect(pos)
+		if st.drag != i && !r.Inside(g.mouseX, g.mouseY) {
+			continue
+		}
+		if st.orientation == HORIZONTAL {
+			for y := r.y; y <= r.y+r.h; y++ {
+				g.buffer.WriteEx(r.x, y, "┃┃", ROLE_ACCENT)
+			}
+		} else {
+			for x := r.x; x <= r.x+r.w; x++ {
+				g.buffer.WriteEx(x, r.y, "━", ROLE_ACCENT)
+				g.buffer.WriteEx(x, r.y+1, "━", ROLE_ACCENT)
+			}
+		}
+	}
+}
+
+// paneOptions returns the size of the current pane
+func (st *splitState) paneOptions() CellOptions {
+	size := st.sizes[st.pane]
+	if st.orientation == HORIZONTAL {
+		return CellOptions{Width: size - 2, Height: st.other - 2}
+	}
+	return CellOptions{Width: st.other - 2, Height: size - 2}
+}
+
+func (g *GUI) startPane(st *splitState) {
+	if st.orientation == VERTICAL && st.pane > 0 {
+		g.buffer.StartRow()
+	}
+	g.buffer.StartCellEx("", st.paneOptions())
+}
+
+// NextPane closes the current pane and starts the next one
+func (g *GUI) NextPane() {
+	if len(g.splitStack) == 0 {
+		return
+	}
+	st := g.splitStack[len(g.splitStack)-1]
+	if st.pane >= len(st.sizes)-1 {
+		return
+	}
+	g.buffer.EndCell()
+	if st.orientation == VERTICAL {
+		g.buffer.EndRow()
+	}
+	st.pane++
+	g.startPane(st)
+}
+
+func (g *GUI) EndSplit() {
+	g.popScope(SCOPE_SPLIT)
+}
+
+func (g *GUI) endSplit() {
+	if len(g.splitStack) == 0 {
+		return
+	}
+	st := g.splitStack[len(g.splitStack)-1]
+	g.splitStack = g.splitStack[:len(g.splitStack)-1]
+	g.buffer.EndCell()
+	// create the panes which were not visited
+	for st.pane < len(st.sizes)-1 {
+		if st.orientation == VERTICAL {
+			g.buffer.EndRow()
+		}
+		st.pane++
+		g.startPane(st)
+		g.buffer.EndCell()
+	}
+	g.buffer.EndRow()
+}
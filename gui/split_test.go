@@ -0,0 +1,76 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func renderSplit(gui *GUI, ratio *float64) {
+	renderSplitEx(gui, ratio, SplitOptions{})
+}
+
+func renderSplitEx(gui *GUI, ratio *float64, opts SplitOptions) {
+	gui.Begin()
+	gui.BeginSplitEx("master", HORIZONTAL, []*float64{ratio}, opts)
+	gui.Text("left")
+	gui.NextPane()
+	gui.Text("right")
+	gui.EndSplit()
+	gui.End()
+}
+
+func TestSplitPanes(t *testing.T) {
+	gui := NewGUI(40, 10)
+	ratio := 0.5
+	renderSplit(gui, &ratio)
+	assert.NoError(t, gui.Err())
+	left := gui.buffer.cells[0]
+	right := gui.buffer.cells[1]
+	assert.Equal(t, rect{x: 1, y: 1, w: 19, h: 7}, left.rect)
+	assert.Equal(t, rect{x: 21, y: 1, w: 19, h: 7}, right.rect)
+}
+
+func TestSplitDrag(t *testing.T) {
+	gui := NewGUI(40, 10)
+	ratio := 0.5
+	renderSplit(gui, &ratio)
+	gui.SetMousePos(tea.MouseEvent{X: 30, Y: 3})
+	gui.SetMouseEvent(tea.MouseEvent{X: 20, Y: 3, Button: tea.MouseButtonLeft})
+	renderSplit(gui, &ratio)
+	// the press itself does not move the divider
+	assert.Equal(t, 0.5, ratio)
+	gui.SetMousePos(tea.MouseEvent{X: 10, Y: 3, Button: tea.MouseButtonLeft})
+	renderSplit(gui, &ratio)
+	assert.Equal(t, 0.25, ratio)
+	assert.Equal(t, 9, gui.buffer.cells[0].w)
+	// the minimum size is kept
+	gui.SetMousePos(tea.MouseEvent{X: 1, Y: 3, Button: tea.MouseButtonLeft})
+	renderSplit(gui, &ratio)
+	assert.Equal(t, float64(SPLIT_MIN_SIZE)/40, ratio)
+	gui.SetMouseRelease(tea.MouseEvent{X: 30, Y: 3})
+	renderSplit(gui, &ratio)
+	assert.Equal(t, float64(SPLIT_MIN_SIZE)/40, ratio)
+}
+
+func TestSplitMinSize(t *testing.T) {
+	gui := NewGUI(40, 10)
+	ratio := 0.5
+	opts := SplitOptions{MinSize: 12}
+	renderSplitEx(gui, &ratio, opts)
+	gui.SetMouseEvent(tea.MouseEvent{X: 20, Y: 3, Button: tea.MouseButtonLeft})
+	renderSplitEx(gui, &ratio, opts)
+	gui.SetMousePos(tea.MouseEvent{X: 1, Y: 3, Button: tea.MouseButtonLeft})
+	renderSplitEx(gui, &ratio, opts)
+	assert.Equal(t, 12.0/40, ratio)
+	// another split keeps the default
+	other := 0.5
+	gui2 := NewGUI(40, 10)
+	renderSplit(gui2, &other)
+	gui2.SetMouseEvent(tea.MouseEvent{X: 20, Y: 3, Button: tea.MouseButtonLeft})
+	renderSplit(gui2, &other)
+	gui2.SetMousePos(tea.MouseEvent{X: 1, Y: 3, Button: tea.MouseButtonLeft})
+	renderSplit(gui2, &other)
+	assert.Equal(t, float64(SPLIT_MIN_SIZE)/40, other)
+}
@@ -1,21 +1,33 @@
 package imgui
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 type Stack struct {
 	items []string
+	names []string
 }
 
 func (st *Stack) Push(s string) {
 	st.items = append(st.items, fmt.Sprintf("%p", &s))
+	st.names = append(st.names, s)
 }
 
 func (st *Stack) Pop() {
 	if !st.IsEmpty() {
 		st.items = st.items[:len(st.items)-1]
+		st.names = st.names[:len(st.names)-1]
 	}
 }
 
+// Path returns the pushed names joined by a slash. Unlike Top it is the
+// same in every frame and identifies the state of a widget
+func (st *Stack) Path() string {
+	return strings.Join(st.names, "/")
+}
+
 func (st *Stack) Top() string {
 	if !st.IsEmpty() {
 		return st.items[len(st.items)-1]
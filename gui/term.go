@@ -3,7 +3,7 @@ package imgui
 import (
 	"fmt"
 	"strings"
-	"unicode/utf8"
+	"sync"
 )
 
 const (
@@ -20,8 +20,38 @@ const (
 type Style struct {
 	foreground Color
 	background Color
-	bold       bool
-	flags      uint8
+	flags      uint16
+}
+
+// the flags of a style
+const (
+	ATTR_FOREGROUND uint16 = 1 << iota
+	ATTR_BACKGROUND
+	ATTR_BOLD
+	ATTR_ITALIC
+	ATTR_UNDERLINE
+	ATTR_DIM
+	ATTR_BLINK
+	ATTR_REVERSE
+	ATTR_STRIKETHROUGH
+	ATTR_OVERLINE
+	ATTR_DOUBLE_UNDERLINE
+	ATTR_CURLY_UNDERLINE
+)
+
+// the SGR parameter of every attribute in the order they are emitted
+var sgrAttributes = []struct {
+	flag uint16
+	code string
+}{
+	{ATTR_BOLD, "1"},
+	{ATTR_DIM, "2"},
+	{ATTR_ITALIC, "3"},
+	{ATTR_UNDERLINE, "4"},
+	{ATTR_BLINK, "5"},
+	{ATTR_REVERSE, "7"},
+	{ATTR_STRIKETHROUGH, "9"},
+	{ATTR_OVERLINE, "53"},
 }
 
 const (
@@ -53,41 +83,6 @@ const (
 var TEXT_STYLE = NewStyle(WHITE, "", false)
 var TEXT_STYLE_ODD = NewStyle(GRAY, "", false)
 
-const (
-	NO_STYLE           = 0
-	INPUT_ACTIVE_STYLE = 1
-	INPUT_STYLE        = 2
-	OK_BUTTON_STYLE    = 3
-	HEADER_STYLE       = 4
-	ARROW_STYLE        = 5
-	TABLE_RED          = 6
-	TABLE_ORANGE       = 7
-	TABLE_BLUE         = 8
-	TABLE_GREEN        = 9
-	TABLE_LIGHT_GREEN  = 10
-	BORDER             = 11
-)
-
-// https://hexdocs.pm/color_palette/ansi_color_codes.html
-
-var STYLES = []Style{
-	NewStyle(WHITE, BACKGROUND_HIGHLIGHTED, true),
-	NewStyle(WHITE, "#1a1a1a", true),
-	NewStyle(WHITE, GREEN, true),
-	NewAnsiStyle(220, 0, true),
-	NewStyle(WHITE, BACKGROUND_HIGHLIGHTED, true),
-
-	// table styles
-	NewStyle("#a21a1a", "", true),
-	NewStyle("#d56f1a", "", true),
-	NewStyle("#1a7091", "", true),
-	NewStyle("#287114", "", true),
-	NewStyle("#389a1d", "", true),
-	//NewStyle("#209c05", "", true),
-
-	NewAnsiStyle(238, 0, true),
-}
-
 /*
 func GetColor(severity int) func(...string) string {
 	switch severity {
@@ -138,18 +133,52 @@ func GetStatusIcon(status apis.ScanningStatus) string {
 }
 */
 
+// custom styles are registered once per process. Their ids start after
+// the roles of the theme so they never collide with a role
+var customStyles = struct {
+	sync.RWMutex
+	ids    map[Style]int
+	styles []Style
+}{ids: make(map[Style]int)}
+
+// AddStyle registers a style at runtime and returns the id which can be
+// passed to Write. Registering the same style twice returns the same id.
+// Unlike the roles of a theme the style looks the same in every GUI
+func AddStyle(s Style) int {
+	customStyles.Lock()
+	defer customStyles.Unlock()
+	if id, ok := customStyles.ids[s]; ok {
+		return id
+	}
+	customStyles.styles = append(customStyles.styles, s)
+	id := ROLE_COUNT + len(customStyles.styles) - 1
+	customStyles.ids[s] = id
+	return id
+}
+
+// customStyle returns the style registered with AddStyle
+func customStyle(id int) Style {
+	customStyles.RLock()
+	defer customStyles.RUnlock()
+	idx := id - ROLE_COUNT
+	if idx < 0 || idx >= len(customStyles.styles) {
+		return Style{}
+	}
+	return customStyles.styles[idx]
+}
+
 func NewStyle(f, b string, bld bool) Style {
 	s := Style{}
 	if f != "" {
 		s.foreground = Hex(f)
-		s.flags = 1
+		s.flags = ATTR_FOREGROUND
 	}
 	if b != "" {
 		s.background = Hex(b)
-		s.flags = s.flags | 2
+		s.flags = s.flags | ATTR_BACKGROUND
 	}
 	if bld {
-		s.flags = s.flags | 4
+		s.flags = s.flags | ATTR_BOLD
 	}
 	return s
 }
@@ -158,14 +187,22 @@ func NewAnsiStyle(f, b int, bld bool) Style {
 	s := Style{}
 	if f != 0 {
 		s.foreground = Hex(ANSI_COLORS[f])
-		s.flags = 1
+		s.flags = ATTR_FOREGROUND
 	}
 	if b != 0 {
 		s.background = Hex(ANSI_COLORS[b])
-		s.flags = s.flags | 2
+		s.flags = s.flags | ATTR_BACKGROUND
 	}
 	if bld {
-		s.flags = s.flags | 4
+		s.flags = s.flags | ATTR_BOLD
+	}
+	return s
+}
+
+func (s Style) Foreground(f string) Style {
+	if f != "" {
+		s.foreground = Hex(f)
+		s.flags = s.flags | ATTR_FOREGROUND
 	}
 	return s
 }
@@ -173,31 +210,130 @@ func NewAnsiStyle(f, b int, bld bool) Style {
 func (s Style) Background(b string) Style {
 	if b != "" {
 		s.background = Hex(b)
-		s.flags = s.flags | 2
+		s.flags = s.flags | ATTR_BACKGROUND
 	}
 	return s
 }
 
+func (s Style) Bold() Style {
+	s.flags |= ATTR_BOLD
+	return s
+}
+
+func (s Style) Dim() Style {
+	s.flags |= ATTR_DIM
+	return s
+}
+
+func (s Style) Italic() Style {
+	s.flags |= ATTR_ITALIC
+	return s
+}
+
+func (s Style) Underline() Style {
+	s.flags = s.flags&^(ATTR_DOUBLE_UNDERLINE|ATTR_CURLY_UNDERLINE) | ATTR_UNDERLINE
+	return s
+}
+
+// DoubleUnderline falls back to a single underline if the terminal
+// does not support 24 bit colors
+func (s Style) DoubleUnderline() Style {
+	s.flags = s.flags&^ATTR_CURLY_UNDERLINE | ATTR_UNDERLINE | ATTR_DOUBLE_UNDERLINE
+	return s
+}
+
+// CurlyUnderline falls back to a single underline if the terminal
+// does not support 24 bit colors
+func (s Style) CurlyUnderline() Style {
+	s.flags = s.flags&^ATTR_DOUBLE_UNDERLINE | ATTR_UNDERLINE | ATTR_CURLY_UNDERLINE
+	return s
+}
+
+func (s Style) Blink() Style {
+	s.flags |= ATTR_BLINK
+	return s
+}
+
+func (s Style) Reverse() Style {
+	s.flags |= ATTR_REVERSE
+	return s
+}
+
+func (s Style) Strikethrough() Style {
+	s.flags |= ATTR_STRIKETHROUGH
+	return s
+}
+
+func (s Style) Overline() Style {
+	s.flags |= ATTR_OVERLINE
+	return s
+}
+
+// Has returns true if all given flags are set
+func (s Style) Has(flags uint16) bool {
+	return s.flags&flags == flags
+}
+
+// the style buffers are reused between calls of Convert
+var stylePool = sync.Pool{New: func() interface{} { return newBuffer() }}
+
 func (s Style) Convert(t string) string {
-	b := newBuffer()
-	if s.flags&4 != 0 {
-		b.bold()
+	b := stylePool.Get().(*styleBuffer)
+	defer stylePool.Put(b)
+	s.apply(b)
+	if b.index == 2 {
+		// nothing to set with the current color profile
+		return t
+	}
+	return b.text(t).String()
+}
+
+// apply appends the attributes and colors to the buffer depending on
+// the color profile. Without colors a background is shown as reverse video
+func (s Style) apply(b *styleBuffer) {
+	profile := CurrentColorProfile()
+	reverse := s.flags&ATTR_REVERSE != 0
+	for _, a := range sgrAttributes {
+		if s.flags&a.flag == 0 {
+			continue
+		}
+		code := a.code
+		if a.flag == ATTR_UNDERLINE && profile == PROFILE_TRUECOLOR {
+			if s.flags&ATTR_DOUBLE_UNDERLINE != 0 {
+				code = "4:2"
+			} else if s.flags&ATTR_CURLY_UNDERLINE != 0 {
+				code = "4:3"
+			}
+		}
+		b.attribute(code)
+	}
+	if profile == PROFILE_MONO {
+		if s.flags&ATTR_BACKGROUND != 0 && !reverse {
+			b.attribute("7")
+		}
+		return
 	}
-	if s.flags&1 != 0 {
+	if s.flags&ATTR_FOREGROUND != 0 {
 		b.forground(s.foreground)
 	}
-	if s.flags&2 != 0 {
+	if s.flags&ATTR_BACKGROUND != 0 {
 		b.background(s.background)
 	}
-	return b.text(t).String()
 }
 
+// params returns the SGR parameters of the style without ESC[ and m
+func (s Style) params(b *styleBuffer) string {
+	s.apply(b)
+	ret := string(b.runes[2:b.index])
+	b.index = 2
+	return ret
+}
+
+// Debug returns the parameters of the escape sequence Convert emits
+// with ESC written as text
 func (s Style) Debug() string {
 	b := newBuffer()
-	if s.bold {
-		b.bold()
-	}
-	b.forground(s.foreground).background(s.background)
+	s.apply(b)
 	txt := ""
 	for i := 2; i < b.index; i++ {
 		r := b.runes[i]
@@ -235,6 +371,30 @@ func Hex(scol string) Color {
 	return Color{r: r, g: g, b: b}
 }
 
+// Lerp interpolates linear between c and o. t is clamped to 0..1
+func (c Color) Lerp(o Color, t float64) Color {
+	if t < 0 {
+		t = 0
+	}
+	if t > 1 {
+		t = 1
+	}
+	l := func(a, b byte) byte {
+		return byte(float64(a) + (float64(b)-float64(a))*t + 0.5)
+	}
+	return Color{r: l(c.r, o.r), g: l(c.g, o.g), b: l(c.b, o.b)}
+}
+
+// Luminance returns the perceived brightness in the range 0..1
+func (c Color) Luminance() float64 {
+	return (0.299*float64(c.r) + 0.587*float64(c.g) + 0.114*float64(c.b)) / 255.0
+}
+
+// String returns the color as hex string like #1a2b3c
+func (c Color) String() string {
+	return fmt.Sprintf("#%02x%02x%02x", c.r, c.g, c.b)
+}
+
 type styleBuffer struct {
 	runes []rune
 	index int
@@ -276,37 +436,49 @@ func (b *styleBuffer) byte(bt byte) {
 	b.append(rune(t + 48))
 }
 
-func (b *styleBuffer) bold() *styleBuffer {
+// attribute appends a SGR parameter like 3 for italic
+func (b *styleBuffer) attribute(a string) *styleBuffer {
 	if b.index > 2 {
 		b.append(';')
 	}
-	b.append('1')
-	return b
+	return b.sequence(a)
 }
 
 func (b *styleBuffer) forground(c Color) *styleBuffer {
-	if b.index > 2 {
-		b.append(';')
-	}
-	b.sequence("38;2;")
-	b.byte(c.r)
-	b.append(';')
-	b.byte(c.g)
-	b.append(';')
-	b.byte(c.b)
-	return b
+	return b.color(c, 30)
 }
 
 func (b *styleBuffer) background(c Color) *styleBuffer {
+	return b.color(c, 40)
+}
+
+// color appends the color in the format of the color profile. Base
+// is 30 for the foreground and 40 for the background
+func (b *styleBuffer) color(c Color, base byte) *styleBuffer {
 	if b.index > 2 {
 		b.append(';')
 	}
-	b.sequence("48;2;")
-	b.byte(c.r)
-	b.append(';')
-	b.byte(c.g)
-	b.append(';')
-	b.byte(c.b)
+	switch CurrentColorProfile() {
+	case PROFILE_ANSI16:
+		idx := byte(nearestAnsi(c, 16))
+		if idx < 8 {
+			b.byte(base + idx)
+		} else {
+			b.byte(base + 60 + idx - 8)
+		}
+	case PROFILE_ANSI256:
+		b.byte(base + 8)
+		b.sequence(";5;")
+		b.byte(byte(nearestAnsi(c, 256)))
+	default:
+		b.byte(base + 8)
+		b.sequence(";2;")
+		b.byte(c.r)
+		b.append(';')
+		b.byte(c.g)
+		b.append(';')
+		b.byte(c.b)
+	}
 	return b
 }
 
@@ -328,7 +500,7 @@ func (b *styleBuffer) sequence(s string) *styleBuffer {
 func (b *styleBuffer) String() string {
 	b.append(ESC)
 	b.append('[')
-	b.append(0)
+	b.append('0')
 	b.append('m')
 	ret := string(b.runes[0:b.index])
 	b.runes[0] = ESC
@@ -353,10 +525,6 @@ func isSequenceChar(r rune) bool {
 	return false
 }
 
-func internalLen(txt string) int {
-	return utf8.RuneCountInString(txt)
-}
-
 func internalLength(s string) int {
 	if strings.Contains(s, CSI) {
 		pos := make([]int, 0)
@@ -0,0 +1,22 @@
+package imgui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestStyleBuilder(t *testing.T) {
+	defer SetColorProfile(CurrentColorProfile())
+	SetColorProfile(PROFILE_TRUECOLOR)
+	s := NewStyle("#ff0000", "", false).Italic().Underline().Dim().Strikethrough().Overline()
+	assert.True(t, s.Has(ATTR_ITALIC|ATTR_UNDERLINE))
+	assert.Equal(t, "2;3;4;9;53;38;2;255;0;0m", s.Debug())
+	assert.True(t, strings.HasPrefix(s.Convert("x"), "\x1b["+strings.TrimSuffix(s.Debug(), "m")+"mx"))
+	assert.Equal(t, "4:3m", Style{}.CurlyUnderline().Debug())
+	assert.Equal(t, "4:2m", Style{}.CurlyUnderline().DoubleUnderline().Debug())
+	assert.Equal(t, "1;5;7m", Style{}.Bold().Blink().Reverse().Debug())
+	SetColorProfile(PROFILE_ANSI256)
+	assert.Equal(t, "4m", Style{}.CurlyUnderline().Debug())
+}
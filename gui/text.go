@@ -0,0 +1,147 @@
+package imgui
+
+import (
+	"strings"
+
+	"github.com/amecky/table/table"
+)
+
+const ELLIPSIS = "…"
+
+// wrapText breaks the text into lines of at most width characters. Lines
+// are broken at spaces and words longer than width are split
+func wrapText(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	ret := make([]string, 0)
+	for _, p := range strings.Split(text, "\n") {
+		line := ""
+		for _, w := range strings.Fields(p) {
+			for internalLen(w) > width {
+				if line != "" {
+					ret = append(ret, line)
+					line = ""
+				}
+				var head string
+				head, w = splitWidthAtLeast(w, width)
+				ret = append(ret, head)
+			}
+			if line == "" {
+				line = w
+			} else if internalLen(line)+1+internalLen(w) <= width {
+				line += " " + w
+			} else {
+				ret = append(ret, line)
+				line = w
+			}
+		}
+		ret = append(ret, line)
+	}
+	return ret
+}
+
+// truncate cuts the text to width characters and marks the cut with an ellipsis
+func truncate(text string, width int) string {
+	if internalLen(text) <= width {
+		return text
+	}
+	if width <= 0 {
+		return ""
+	}
+	head, _ := splitWidth(text, width-1)
+	return head + ELLIPSIS
+}
+
+// AvailableWidth returns the number of columns from the cursor to the
+// right side of the current cell. For cells sized by their content the
+// right side of the screen is used
+func (b *Buffer) AvailableWidth() int {
+	if b.curCell >= len(b.cells) {
+		return b.width - b.curX
+	}
+	c := b.cells[b.curCell]
+	w := b.width - c.x - 2
+	if c.opts.Width > 0 {
+		w = c.opts.Width
+	} else if (c.opts.Fill || c.opts.Percent > 0) && b.curCell < len(b.resolved) && b.resolved[b.curCell] > 0 {
+		w = b.resolved[b.curCell] - 1
+	}
+	return w - (b.curX - c.x)
+}
+
+// WriteAligned writes a line which is aligned inside the current cell
+// once the size of the cell is known
+func (b *Buffer) WriteAligned(txt string, style int, align table.TextAlign) {
+	b.Write(txt, style, false)
+	c := &b.commands[len(b.commands)-1]
+	c.aligned = true
+	c.align = align
+}
+
+// alignCommands moves all aligned commands inside their cell
+func (b *Buffer) alignCommands() {
+	for i := range b.commands {
+		c := &b.commands[i]
+		if !c.aligned || c.cellIdx < 0 || c.cellIdx >= len(b.cells) {
+			continue
+		}
+		cl := b.cells[c.cellIdx]
+		start := b.lineStartOf(c.cellIdx)
+		// cells sized by their content keep one column of padding
+		right := cl.x + cl.w - 2
+		if cl.sized() {
+			right = cl.x + cl.w - 1
+		}
+		c.x = start + alignOffset(c.text, right-start, c.align)
+	}
+}
+
+// alignOffset returns the number of columns formatString puts in front
+// of the text
+func alignOffset(txt string, width int, align table.TextAlign) int {
+	d := max(width-internalLen(txt), 0)
+	switch align {
+	case table.AlignRight:
+		return d
+	case table.AlignCenter:
+		return d / 2
+	}
+	return 0
+}
+
+// lineStartOf returns the x position where a line starts in the cell
+func (b *Buffer) lineStartOf(idx int) int {
+	return b.cells[idx].x + b.cells[idx].indent
+}
+
+// TextWrapped writes the text broken into lines which fit into the current cell
+func (g *GUI) TextWrapped(text string) {
+	g.buffer.PushID(text)
+	for _, l := range wrapText(text, g.buffer.AvailableWidth()) {
+		g.buffer.Write(l, 0, false)
+	}
+	g.buffer.PopID()
+}
+
+// TextAligned writes the text aligned left, right or centered inside the current cell
+func (g *GUI) TextAligned(text string, align table.TextAlign) {
+	g.buffer.PushID(text)
+	for _, l := range strings.Split(text, "\n") {
+		g.buffer.WriteAligned(l, 0, align)
+	}
+	g.buffer.PopID()
+}
+
+// TextEllipsis writes the text cut to maxWidth characters. If maxWidth
+// is zero the available width of the cell is used
+func (g *GUI) TextEllipsis(text string, maxWidth int) {
+	if maxWidth <= 0 {
+		maxWidth = g.buffer.AvailableWidth()
+	}
+	g.buffer.PushID(text)
+	for _, l := range strings.Split(text, "\n") {
+		g.buffer.Write(truncate(l, maxWidth), 0, false)
+	}
+	g.buffer.PopID()
+}
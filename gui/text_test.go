@@ -0,0 +1,67 @@
+package imgui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/amecky/table/table"
+)
+
+func TestWrapText(t *testing.T) {
+	assert.Equal(t, []string{"the quick", "brown fox", "jumps"}, wrapText("the quick brown fox jumps", 10))
+	assert.Equal(t, []string{"abcd", "efgh", "ij", "one"}, wrapText("abcdefghij\none", 4))
+	assert.Equal(t, []string{"a", ""}, wrapText("a\n", 4))
+}
+
+func TestTruncate(t *testing.T) {
+	assert.Equal(t, "Hello", truncate("Hello", 5))
+	assert.Equal(t, "Hel…", truncate("Hello", 4))
+	assert.Equal(t, "", truncate("Hello", 0))
+}
+
+func TestTextLines(t *testing.T) {
+	gui := NewGUI(30, 10)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("one\ntwo")
+	gui.EndCell()
+	gui.StartCellEx("", CellOptions{Width: 10})
+	gui.TextWrapped("the quick brown fox")
+	gui.TextAligned("right", table.AlignRight)
+	gui.TextAligned("mid", table.AlignCenter)
+	gui.TextEllipsis("a very long text", 0)
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+
+	r, _ := gui.buffer.At(1, 2)
+	assert.Equal(t, 't', r)
+	c := gui.buffer.cells[1]
+	lines := []string{"the quick", "brown fox", "     right", "   mid", "a very lo…"}
+	for i, l := range lines {
+		for j, ch := range []rune(l) {
+			r, _ := gui.buffer.At(c.x+j, c.y+i)
+			assert.Equal(t, ch, r, "line %d", i)
+		}
+	}
+}
+
+func TestAlignOffset(t *testing.T) {
+	assert.Equal(t, 0, alignOffset("abc", 10, table.AlignLeft))
+	assert.Equal(t, 7, alignOffset("abc", 10, table.AlignRight))
+	assert.Equal(t, 3, alignOffset("abc", 10, table.AlignCenter))
+	// wide characters take two columns
+	assert.Equal(t, 6, alignOffset("日本", 10, table.AlignRight))
+	assert.Equal(t, 0, alignOffset("too long", 4, table.AlignRight))
+	assert.Equal(t, 3, alignOffset("日本", 10, table.AlignCenter))
+	assert.Equal(t, 8, alignOffset("äö", 10, table.AlignRight))
+	// the offset matches the padding of formatString
+	for _, txt := range []string{"abc", "日本", "äö", "x日"} {
+		for _, align := range []table.TextAlign{table.AlignLeft, table.AlignRight, table.AlignCenter} {
+			s := formatString(txt, 9, align)
+			assert.Equal(t, strings.Repeat(" ", alignOffset(txt, 9, align))+txt, strings.TrimRight(s, " "))
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package imgui
+
+// The style ids passed to Write. Every id below ROLE_COUNT is a role
+// which is resolved by the theme of the GUI. Ids returned by AddStyle
+// follow after the roles
+const (
+	ROLE_TEXT = iota
+	ROLE_INPUT_ACTIVE
+	ROLE_INPUT
+	ROLE_BUTTON
+	ROLE_HEADER
+	ROLE_HIGHLIGHT
+	ROLE_DANGER
+	ROLE_WARNING
+	ROLE_INFO
+	ROLE_SUCCESS
+	ROLE_SUCCESS_LIGHT
+	ROLE_BORDER
+	ROLE_BORDER_FOCUSED
+	ROLE_TEXT_DIM
+	ROLE_BUTTON_HOVER
+	ROLE_ACCENT
+	ROLE_CODE
+	ROLE_ROW_ODD
+	ROLE_COUNT
+)
+
+// the names of the roles used in theme files
+var ROLE_NAMES = []string{
+	"text",
+	"input-active",
+	"input",
+	"button",
+	"header",
+	"highlight",
+	"danger",
+	"warning",
+	"info",
+	"success",
+	"success-light",
+	"border",
+	"border-focused",
+	"text-dim",
+	"button-hover",
+	"accent",
+	"code",
+	"row-odd",
+}
+
+// the old style ids are kept as names for the roles
+const (
+	NO_STYLE           = ROLE_TEXT
+	INPUT_ACTIVE_STYLE = ROLE_INPUT_ACTIVE
+	INPUT_STYLE        = ROLE_INPUT
+	OK_BUTTON_STYLE    = ROLE_BUTTON
+	HEADER_STYLE       = ROLE_HEADER
+	ARROW_STYLE        = ROLE_HIGHLIGHT
+	TABLE_RED          = ROLE_DANGER
+	TABLE_ORANGE       = ROLE_WARNING
+	TABLE_BLUE         = ROLE_INFO
+	TABLE_GREEN        = ROLE_SUCCESS
+	TABLE_LIGHT_GREEN  = ROLE_SUCCESS_LIGHT
+	BORDER             = ROLE_BORDER
+	FOCUSED_BORDER     = ROLE_BORDER_FOCUSED
+	FORM_HELP_STYLE    = ROLE_TEXT_DIM
+)
+
+// Theme defines the look of a GUI. Text is used for everything written
+// without a style and an empty style keeps the colors of the terminal.
+// RowOdd is the background of every second table row
+type Theme struct {
+	Name          string
+	Borders       BorderSet
+	Text          Style
+	InputActive   Style
+	Input         Style
+	Button        Style
+	ButtonHover   Style
+	Header        Style
+	Highlight     Style
+	Danger        Style
+	Warning       Style
+	Info          Style
+	Success       Style
+	SuccessLight  Style
+	Border        Style
+	BorderFocused Style
+	TextDim       Style
+	Accent        Style
+	Code          Style
+	RowOdd        Style
+}
+
+// roles returns the styles of the theme in the order of the role ids
+func (t *Theme) roles() []*Style {
+	return []*Style{
+		&t.Text,
+		&t.InputActive,
+		&t.Input,
+		&t.Button,
+		&t.Header,
+		&t.Highlight,
+		&t.Danger,
+		&t.Warning,
+		&t.Info,
+		&t.Success,
+		&t.SuccessLight,
+		&t.Border,
+		&t.BorderFocused,
+		&t.TextDim,
+		&t.ButtonHover,
+		&t.Accent,
+		&t.Code,
+		&t.RowOdd,
+	}
+}
+
+// Style returns the style of a role or of a style registered with AddStyle
+func (t *Theme) Style(id int) Style {
+	if id >= 0 && id < ROLE_COUNT {
+		return *t.roles()[id]
+	}
+	return customStyle(id)
+}
+
+// palette resolves all roles at once for drawing a frame
+func (t *Theme) palette() []Style {
+	ret := make([]Style, ROLE_COUNT)
+	for i, s := range t.roles() {
+		ret[i] = *s
+	}
+	return ret
+}
+
+func DarkTheme() *Theme {
+	return &Theme{
+		Name:          "dark",
+		Borders:       BORDER_SINGLE,
+		InputActive:   NewStyle(WHITE, BACKGROUND_HIGHLIGHTED, true),
+		Input:         NewStyle(WHITE, "#1a1a1a", true),
+		Button:        NewStyle(WHITE, GREEN, true),
+		ButtonHover:   NewStyle(BRIGHT_WHITE, "#5fae0a", true),
+		Header:        NewAnsiStyle(220, 0, true),
+		Highlight:     NewStyle(WHITE, BACKGROUND_HIGHLIGHTED, true),
+		Danger:        NewStyle("#a21a1a", "", true),
+		Warning:       NewStyle("#d56f1a", "", true),
+		Info:          NewStyle("#1a7091", "", true),
+		Success:       NewStyle("#287114", "", true),
+		SuccessLight:  NewStyle("#389a1d", "", true),
+		Border:        NewAnsiStyle(238, 0, true),
+		BorderFocused: NewStyle(BRIGHT_BLUE, "", true),
+		TextDim:       TEXT_STYLE_ODD,
+		Accent:        NewStyle(BRIGHT_BLUE, "", true),
+		Code:          NewStyle(WHITE, "#1a1a1a", false),
+		RowOdd:        NewStyle("", BACKGROUND_ODD, false),
+	}
+}
+
+func LightTheme() *Theme {
+	return &Theme{
+		Name:          "light",
+		Borders:       BORDER_SINGLE,
+		Text:          NewStyle("#1e1e1e", "", false),
+		InputActive:   NewStyle(BLACK, "#c8d4e6", true),
+		Input:         NewStyle(BLACK, "#e4e4e4", true),
+		Button:        NewStyle(BRIGHT_WHITE, GREEN, true),
+		ButtonHover:   NewStyle(BRIGHT_WHITE, "#3b7a05", true),
+		Header:        NewStyle("#005f87", "", true),
+		Highlight:     NewStyle(BLACK, "#c8d4e6", true),
+		Danger:        NewStyle("#c00000", "", true),
+		Warning:       NewStyle("#b35900", "", true),
+		Info:          NewStyle("#005f87", "", true),
+		Success:       NewStyle("#2e6b0f", "", true),
+		SuccessLight:  NewStyle("#3f8f1a", "", true),
+		Border:        NewStyle("#a8a8a8", "", false),
+		BorderFocused: NewStyle(BLUE, "", true),
+		TextDim:       NewStyle("#767676", "", false),
+		Accent:        NewStyle(BLUE, "", true),
+		Code:          NewStyle(BLACK, "#eeeeee", false),
+		RowOdd:        NewStyle("", "#f2f2f2", false),
+	}
+}
+
+func HighContrastTheme() *Theme {
+	return &Theme{
+		Name:          "high-contrast",
+		Borders:       BORDER_THICK,
+		Text:          NewStyle("#ffffff", "", false),
+		InputActive:   NewStyle("#000000", "#ffff00", true),
+		Input:         NewStyle("#000000", "#ffffff", true),
+		Button:        NewStyle("#000000", "#00ff00", true),
+		ButtonHover:   NewStyle("#000000", "#ffff00", true),
+		Header:        NewStyle("#ffff00", "", true),
+		Highlight:     NewStyle("#000000", "#00ffff", true),
+		Danger:        NewStyle("#ff0000", "", true),
+		Warning:       NewStyle("#ff8700", "", true),
+		Info:          NewStyle("#00ffff", "", true),
+		Success:       NewStyle("#00ff00", "", true),
+		SuccessLight:  NewStyle("#87ff87", "", true),
+		Border:        NewStyle("#ffffff", "", true),
+		BorderFocused: NewStyle("#ffff00", "", true),
+		TextDim:       NewStyle("#d0d0d0", "", false),
+		Accent:        NewStyle("#00ffff", "", true),
+		Code:          NewStyle("#ffffff", "#303030", false),
+		RowOdd:        NewStyle("", "#262626", false),
+	}
+}
+
+// SetTheme changes the look of the GUI starting with the next frame
+func (g *GUI) SetTheme(t *Theme) {
+	g.buffer.theme = t
+	g.buffer.Invalidate()
+}
+
+func (g *GUI) Theme() *Theme {
+	return g.buffer.theme
+}
@@ -0,0 +1,454 @@
+package imgui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// A theme file contains the name, the border set and one table per role:
+//
+//	name = "solarized"
+//	borders = "rounded"
+//
+//	[header]
+//	fg = "#b58900"
+//	bg = 235
+//	attrs = ["bold", "underline"]
+//
+// Colors are hex strings or indices into ANSI_COLORS. Roles which are
+// not defined in the file are taken from the dark theme. JSON files use
+// the same keys with the roles as nested objects.
+
+var BORDER_SETS = map[string]BorderSet{
+	"single":  BORDER_SINGLE,
+	"rounded": BORDER_ROUNDED,
+	"double":  BORDER_DOUBLE,
+	"thick":   BORDER_THICK,
+	"ascii":   BORDER_ASCII,
+	"none":    BORDER_NONE,
+}
+
+// the attributes of a style by name and the flag which is set
+var ATTRIBUTE_NAMES = []string{"bold", "dim", "italic", "underline", "double-underline", "curly-underline", "blink", "reverse", "strikethrough", "overline"}
+var ATTRIBUTE_FLAGS = []uint16{ATTR_BOLD, ATTR_DIM, ATTR_ITALIC, ATTR_UNDERLINE, ATTR_DOUBLE_UNDERLINE, ATTR_CURLY_UNDERLINE, ATTR_BLINK, ATTR_REVERSE, ATTR_STRIKETHROUGH, ATTR_OVERLINE}
+
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// the table header and the key of a line in a TOML file
+var tomlTable = regexp.MustCompile(`^\s*\[+\s*([^\]]+?)\s*\]+`)
+var tomlKey = regexp.MustCompile(`^\s*([A-Za-z0-9_.-]+)\s*=`)
+
+// expandHex validates #rgb or #rrggbb and returns the long form
+// which can be passed to Hex so #fff is white
+func expandHex(s string) (string, bool) {
+	if !hexColor.MatchString(s) {
+		return "", false
+	}
+	if len(s) == 4 {
+		s = string([]byte{'#', s[1], s[1], s[2], s[2], s[3], s[3]})
+	}
+	return s, true
+}
+
+// ThemeError points at the key of a theme file which is invalid. Line
+// is only known for syntax errors in TOML files
+type ThemeError struct {
+	Line int
+	Key  string
+	Msg  string
+}
+
+func (e *ThemeError) Error() string {
+	ret := ""
+	if e.Line > 0 {
+		ret = fmt.Sprintf("line %d: ", e.Line)
+	}
+	if e.Key != "" {
+		ret += e.Key + ": "
+	}
+	return ret + e.Msg
+}
+
+// LoadTheme reads a theme from a .json or .toml file
+func LoadTheme(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	var order []string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		err = dec.Decode(&doc)
+		if err == nil {
+			order, err = jsonKeys(data)
+		}
+	case ".toml":
+		doc, order, err = parseTOML(string(data))
+	default:
+		err = fmt.Errorf("unsupported theme format %q", filepath.Ext(path))
+	}
+	if err == nil {
+		var t *Theme
+		t, err = themeFromMap(doc, order)
+		if err == nil {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", path, err)
+}
+
+// SaveTheme writes the theme to a .json or .toml file
+func SaveTheme(path string, t *Theme) error {
+	var data []byte
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		doc := map[string]interface{}{
+			"name":    t.Name,
+			"borders": borderName(t.Borders),
+		}
+		for i, s := range t.roles() {
+			doc[ROLE_NAMES[i]] = styleMap(*s)
+		}
+		var err error
+		data, err = json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return err
+		}
+	case ".toml":
+		data = []byte(themeTOML(t))
+	default:
+		return fmt.Errorf("%s: unsupported theme format %q", path, filepath.Ext(path))
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+func roleIndex(name string) int {
+	for i, n := range ROLE_NAMES {
+		if n == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func borderName(b BorderSet) string {
+	for n, s := range BORDER_SETS {
+		if s == b {
+			return n
+		}
+	}
+	return string([]rune{b.Horizontal, b.Vertical, b.TopLeft, b.TopRight, b.BottomLeft, b.BottomRight, b.LeftT, b.RightT})
+}
+
+// parseBorders accepts the name of a border set or the eight characters
+// in the order of the fields of BorderSet
+func parseBorders(s string) (BorderSet, bool) {
+	if b, ok := BORDER_SETS[s]; ok {
+		return b, true
+	}
+	r := []rune(s)
+	if len(r) != 8 {
+		return BorderSet{}, false
+	}
+	return BorderSet{r[0], r[1], r[2], r[3], r[4], r[5], r[6], r[7]}, true
+}
+
+// styleMap converts the style into the keys of a theme file
+func styleMap(s Style) map[string]interface{} {
+	ret := make(map[string]interface{})
+	if s.flags&ATTR_FOREGROUND != 0 {
+		ret["fg"] = s.foreground.String()
+	}
+	if s.flags&ATTR_BACKGROUND != 0 {
+		ret["bg"] = s.background.String()
+	}
+	attrs := make([]string, 0)
+	for i, f := range ATTRIBUTE_FLAGS {
+		if s.flags&f != 0 {
+			attrs = append(attrs, ATTRIBUTE_NAMES[i])
+		}
+	}
+	if len(attrs) > 0 {
+		ret["attrs"] = attrs
+	}
+	return ret
+}
+
+func themeTOML(t *Theme) string {
+	var sb strings.Builder
+	sb.WriteString("name = " + strconv.Quote(t.Name) + "\n")
+	sb.WriteString("borders = " + strconv.Quote(borderName(t.Borders)) + "\n")
+	for i, s := range t.roles() {
+		sb.WriteString("\n[" + ROLE_NAMES[i] + "]\n")
+		m := styleMap(*s)
+		for _, k := range []string{"fg", "bg"} {
+			if v, ok := m[k]; ok {
+				sb.WriteString(k + " = " + strconv.Quote(v.(string)) + "\n")
+			}
+		}
+		if attrs, ok := m["attrs"]; ok {
+			q := make([]string, 0)
+			for _, a := range attrs.([]string) {
+				q = append(q, strconv.Quote(a))
+			}
+			sb.WriteString("attrs = [" + strings.Join(q, ", ") + "]\n")
+		}
+	}
+	return sb.String()
+}
+
+// themeFromMap validates the keys of a theme file and converts them
+// into a theme based on the dark theme. The keys are checked in the
+// order of the file so the first invalid key is reported
+func themeFromMap(doc map[string]interface{}, order []string) (*Theme, error) {
+	t := DarkTheme()
+	t.Name = ""
+	fail := func(key, format string, args ...interface{}) error {
+		return &ThemeError{Key: key, Msg: fmt.Sprintf(format, args...)}
+	}
+	roles := t.roles()
+	for _, key := range orderedKeys(doc, "", order) {
+		v := doc[key]
+		switch key {
+		case "name":
+			s, ok := v.(string)
+			if !ok {
+				return nil, fail(key, "expected a string")
+			}
+			t.Name = s
+		case "borders":
+			s, ok := v.(string)
+			if !ok {
+				return nil, fail(key, "expected a string")
+			}
+			b, ok := parseBorders(s)
+			if !ok {
+				return nil, fail(key, "unknown border set %q", s)
+			}
+			t.Borders = b
+		default:
+			idx := roleIndex(key)
+			if idx == -1 {
+				return nil, fail(key, "unknown role")
+			}
+			m, ok := v.(map[string]interface{})
+			if !ok {
+				return nil, fail(key, "expected a table with fg, bg and attrs")
+			}
+			s, err := styleFromMap(key, m, order, fail)
+			if err != nil {
+				return nil, err
+			}
+			*roles[idx] = s
+		}
+	}
+	return t, nil
+}
+
+func styleFromMap(role string, m map[string]interface{}, order []string, fail func(string, string, ...interface{}) error) (Style, error) {
+	s := Style{}
+	for _, k := range orderedKeys(m, role+".", order) {
+		key := role + "." + k
+		v := m[k]
+		switch k {
+		case "fg", "bg":
+			c, err := parseColorValue(v)
+			if err != nil {
+				return s, fail(key, "%s", err.Error())
+			}
+			if k == "fg" {
+				s.foreground = c
+				s.flags |= ATTR_FOREGROUND
+			} else {
+				s.background = c
+				s.flags |= ATTR_BACKGROUND
+			}
+		case "attrs":
+			list, ok := v.([]interface{})
+			if !ok {
+				return s, fail(key, "expected a list of attributes")
+			}
+			for _, a := range list {
+				name, _ := a.(string)
+				found := false
+				for i, n := range ATTRIBUTE_NAMES {
+					if n == name {
+						s.flags |= ATTRIBUTE_FLAGS[i]
+						found = true
+					}
+				}
+				if !found {
+					return s, fail(key, "unknown attribute %v", a)
+				}
+			}
+		default:
+			return s, fail(key, "unknown key")
+		}
+	}
+	return s, nil
+}
+
+// parseColorValue converts a hex string or an index into ANSI_COLORS
+func parseColorValue(v interface{}) (Color, error) {
+	switch c := v.(type) {
+	case string:
+		h, ok := expandHex(c)
+		if !ok {
+			return Color{}, fmt.Errorf("invalid color %q", c)
+		}
+		return Hex(h), nil
+	case json.Number:
+		i, err := c.Int64()
+		if err != nil {
+			return Color{}, fmt.Errorf("invalid color %s", c)
+		}
+		return ansiColor(i)
+	case int64:
+		return ansiColor(c)
+	case float64:
+		if c != math.Trunc(c) {
+			return Color{}, fmt.Errorf("invalid color %v", c)
+		}
+		return ansiColor(int64(c))
+	}
+	return Color{}, fmt.Errorf("expected a hex string or an ANSI color index")
+}
+
+func ansiColor(i int64) (Color, error) {
+	if i < 0 || i >= int64(len(ANSI_COLORS)) {
+		return Color{}, fmt.Errorf("ANSI color %d out of range 0..%d", i, len(ANSI_COLORS)-1)
+	}
+	return Hex(ANSI_COLORS[i]), nil
+}
+
+// orderedKeys returns the keys of the map in the order of the file.
+// Order contains the dotted paths of all keys
+func orderedKeys(m map[string]interface{}, prefix string, order []string) []string {
+	pos := make(map[string]int, len(order))
+	for i, k := range order {
+		if _, ok := pos[k]; !ok {
+			pos[k] = i
+		}
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		pi, oki := pos[prefix+keys[i]]
+		pj, okj := pos[prefix+keys[j]]
+		if oki != okj {
+			return oki
+		}
+		if pi != pj {
+			return pi < pj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// parseTOML decodes a TOML document. Returns the dotted paths of all keys
+// in the order of the file
+func parseTOML(src string) (map[string]interface{}, []string, error) {
+	doc := make(map[string]interface{})
+	md, err := toml.Decode(src, &doc)
+	if err != nil {
+		var pe toml.ParseError
+		if errors.As(err, &pe) {
+			msg := pe.Message
+			if msg == "" {
+				// errors of the lexer only carry the text of the error
+				pe.LastKey = ""
+				msg = strings.TrimPrefix(pe.Error(), fmt.Sprintf("toml: line %d: ", pe.Position.Line))
+			}
+			return nil, nil, &ThemeError{Line: pe.Position.Line, Key: tomlKeyAt(src, pe.Position.Line, pe.LastKey), Msg: msg}
+		}
+		return nil, nil, err
+	}
+	order := make([]string, 0)
+	for _, k := range md.Keys() {
+		order = append(order, k.String())
+	}
+	return doc, order, nil
+}
+
+// tomlKeyAt returns the dotted path of the key defined in the line. If
+// the line has no key the table or the fallback is returned
+func tomlKeyAt(src string, line int, fallback string) string {
+	lines := strings.Split(src, "\n")
+	if line < 1 || line > len(lines) {
+		return fallback
+	}
+	table := ""
+	for _, l := range lines[:line-1] {
+		if m := tomlTable.FindStringSubmatch(l); m != nil {
+			table = m[1]
+		}
+	}
+	l := lines[line-1]
+	if m := tomlTable.FindStringSubmatch(l); m != nil {
+		return m[1]
+	}
+	m := tomlKey.FindStringSubmatch(l)
+	if m == nil {
+		if table != "" {
+			return table
+		}
+		return fallback
+	}
+	if table == "" {
+		return m[1]
+	}
+	return table + "." + m[1]
+}
+
+// jsonKeys returns the dotted paths of all object keys in the order of the file
+func jsonKeys(data []byte) ([]string, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	ret := make([]string, 0)
+	var walk func(prefix string) error
+	walk = func(prefix string) error {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		d, ok := tok.(json.Delim)
+		if !ok {
+			return nil
+		}
+		for dec.More() {
+			if d == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				key := prefix + k.(string)
+				ret = append(ret, key)
+				if err := walk(key + "."); err != nil {
+					return err
+				}
+			} else if err := walk(prefix); err != nil {
+				return err
+			}
+		}
+		// the closing delimiter
+		_, err = dec.Token()
+		return err
+	}
+	return ret, walk("")
+}
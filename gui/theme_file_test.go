@@ -0,0 +1,108 @@
+package imgui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+const TEST_THEME = `# my colors
+name = "solarized"
+borders = "rounded"
+
+[header]
+fg = "#b58900" # yellow
+bg = 235
+attrs = ["bold", "underline"]
+`
+
+func TestLoadThemeTOML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.toml")
+	assert.NoError(t, os.WriteFile(path, []byte(TEST_THEME), 0644))
+	th, err := LoadTheme(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "solarized", th.Name)
+	assert.Equal(t, BORDER_ROUNDED, th.Borders)
+	assert.Equal(t, Hex("#b58900"), th.Header.foreground)
+	assert.Equal(t, Hex(ANSI_COLORS[235]), th.Header.background)
+	assert.Equal(t, uint16(1|2|4|16), th.Header.flags)
+	// roles which are not defined are taken from the dark theme
+	assert.Equal(t, DarkTheme().Button, th.Button)
+}
+
+func TestThemeErrors(t *testing.T) {
+	dir := t.TempDir()
+	check := func(name, content, msg string) {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+		_, err := LoadTheme(path)
+		assert.Error(t, err)
+		var te *ThemeError
+		assert.True(t, errors.As(err, &te), name)
+		assert.Equal(t, msg, te.Error())
+	}
+	check("a.toml", "[header]\nfg = \"#zz0000\"\n", `header.fg: invalid color "#zz0000"`)
+	check("b.toml", "[header]\n\nbg = 300\n", "header.bg: ANSI color 300 out of range 0..255")
+	check("c.toml", "[buton]\n", "buton: unknown role")
+	check("d.json", `{"input": {"attrs": ["blinking"]}}`, "input.attrs: unknown attribute blinking")
+	check("e.toml", "borders = \"wavy\"", `borders: unknown border set "wavy"`)
+	// the first invalid key of the file is reported
+	check("f.toml", "[warning]\nfg = 999\n[button]\nbg = 300\n", "warning.fg: ANSI color 999 out of range 0..255")
+	check("g.json", `{"warning": {"fg": 999}, "button": {"bg": 300}}`, "warning.fg: ANSI color 999 out of range 0..255")
+	// syntax errors have a line
+	check("h.toml", "[header]\nfg = \"#ffffff\"\nbg = 12x\n", "line 3: header.bg: expected a top-level item to end with a newline, comment, or EOF, but got 'x' instead")
+}
+
+func TestLoadThemeTOMLSyntax(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "theme.toml")
+	src := "header = { fg = \"#b58900\", bg = 235.0 }\n\n[info]\nattrs = [\n  \"bold\",\n  \"italic\",\n]\n"
+	assert.NoError(t, os.WriteFile(path, []byte(src), 0644))
+	th, err := LoadTheme(path)
+	assert.NoError(t, err)
+	assert.Equal(t, Hex("#b58900"), th.Header.foreground)
+	assert.Equal(t, Hex(ANSI_COLORS[235]), th.Header.background)
+	assert.True(t, th.Info.Has(ATTR_BOLD|ATTR_ITALIC))
+}
+
+func TestLoadThemeShortHex(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "short.toml")
+	assert.NoError(t, os.WriteFile(path, []byte("[header]\nfg = \"#fff\"\nbg = \"#1a2\"\n"), 0644))
+	th, err := LoadTheme(path)
+	assert.NoError(t, err)
+	assert.Equal(t, Hex("#ffffff"), th.Header.foreground)
+	assert.Equal(t, Hex("#11aa22"), th.Header.background)
+	// the expanded colors survive saving and loading
+	out := filepath.Join(dir, "saved.toml")
+	assert.NoError(t, SaveTheme(out, th))
+	saved, err := LoadTheme(out)
+	assert.NoError(t, err)
+	assert.Equal(t, th, saved)
+}
+
+func TestThemeSyntaxErrorKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dup.toml")
+	assert.NoError(t, os.WriteFile(path, []byte("[header]\nfg = \"#fff\"\n\n[info]\nfg = \"#000\"\nfg = \"#111\"\n"), 0644))
+	_, err := LoadTheme(path)
+	var te *ThemeError
+	assert.True(t, errors.As(err, &te))
+	assert.Equal(t, 6, te.Line)
+	assert.Equal(t, "info.fg", te.Key)
+	assert.NotEqual(t, "", te.Msg)
+	assert.False(t, strings.HasPrefix(te.Msg, "toml:"))
+}
+
+func TestSaveTheme(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"theme.json", "theme.toml"} {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, SaveTheme(path, HighContrastTheme()))
+		th, err := LoadTheme(path)
+		assert.NoError(t, err)
+		assert.Equal(t, HighContrastTheme(), th)
+	}
+}
@@ -0,0 +1,69 @@
+package imgui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/alecthomas/assert"
+)
+
+func TestThemeRoles(t *testing.T) {
+	th := DarkTheme()
+	assert.Equal(t, ROLE_COUNT, len(th.roles()))
+	assert.Equal(t, ROLE_COUNT, len(ROLE_NAMES))
+	assert.Equal(t, th.Danger, th.Style(TABLE_RED))
+	id := AddStyle(NewStyle("#123456", "", false))
+	assert.True(t, id >= ROLE_COUNT)
+	assert.Equal(t, Hex("#123456"), th.Style(id).foreground)
+}
+
+func TestTwoThemes(t *testing.T) {
+	frame := func(g *GUI) string {
+		g.Begin()
+		g.Text("Hello")
+		return g.End()
+	}
+	dark := NewGUI(20, 5)
+	light := NewGUI(20, 5)
+	light.SetTheme(LightTheme())
+	d := frame(dark)
+	l := frame(light)
+	assert.NotEqual(t, d, l)
+	// the text of the dark theme keeps the terminal colors
+	assert.True(t, strings.Contains(d, "mHello"))
+	assert.True(t, strings.Contains(l, LightTheme().Text.params(newBuffer())+"mHello"))
+	light.SetBorder(BORDER_DOUBLE)
+	assert.Equal(t, BORDER_SINGLE, dark.Theme().Borders)
+	// a theme shared by several GUIs keeps its border
+	shared := DarkTheme()
+	dark.SetTheme(shared)
+	light.SetTheme(shared)
+	light.SetBorder(BORDER_ROUNDED)
+	assert.Equal(t, BORDER_SINGLE, shared.Borders)
+	assert.Equal(t, BORDER_ROUNDED, light.buffer.border(cell{}))
+	assert.Equal(t, BORDER_SINGLE, dark.buffer.border(cell{}))
+	// resizing keeps the theme and the border
+	resized := light.resize(30, 8)
+	assert.Equal(t, shared, resized.Theme())
+	assert.Equal(t, BORDER_ROUNDED, resized.buffer.border(cell{}))
+}
+
+func TestThemeWidgetColors(t *testing.T) {
+	light := LightTheme()
+	spans := parseRich("[code]x[/] [link=a]y[/]", light)
+	assert.Equal(t, light.Code.background, spans[0].style.background)
+	assert.Equal(t, light.Accent.foreground, spans[2].style.foreground)
+
+	gui := NewGUI(30, 4)
+	gui.SetTheme(light)
+	gui.Begin()
+	gui.ProgressBar(0.5, 10, "")
+	gui.End()
+	_, st := gui.buffer.At(8, 1)
+	assert.Equal(t, light.Code.background, customStyle(st).background)
+	_, st = gui.buffer.At(1, 1)
+	assert.Equal(t, light.Button.background, customStyle(st).foreground)
+
+	assert.Equal(t, light.Accent.foreground, customStyle(seriesStyle(light, "", 0)).foreground)
+	assert.True(t, HighContrastTheme().RowOdd.Has(ATTR_BACKGROUND))
+}
@@ -0,0 +1,98 @@
+package imgui
+
+import (
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// WIDE_CONTINUATION marks the second slot of a double width character
+const WIDE_CONTINUATION = rune(-1)
+
+// internalLen returns the number of columns the text takes on the screen.
+// Grapheme clusters like emoji sequences or letters with combining marks
+// count as one character and east asian wide characters take two columns
+func internalLen(txt string) int {
+	return uniseg.StringWidth(txt)
+}
+
+// splitWidth splits the text after at most width columns without
+// breaking a grapheme cluster
+func splitWidth(s string, width int) (string, string) {
+	rest := s
+	state := -1
+	used := 0
+	for len(rest) > 0 {
+		_, r, w, st := uniseg.FirstGraphemeClusterInString(rest, state)
+		if used+w > width {
+			break
+		}
+		used += w
+		rest = r
+		state = st
+	}
+	return s[:len(s)-len(rest)], rest
+}
+
+// splitWidthAtLeast works like splitWidth but returns at least the
+// first grapheme cluster even if it is wider than width
+func splitWidthAtLeast(s string, width int) (string, string) {
+	head, rest := splitWidth(s, width)
+	if head == "" && rest != "" {
+		head, rest, _, _ = uniseg.FirstGraphemeClusterInString(s, -1)
+	}
+	return head, rest
+}
+
+// release removes a double width character which is partly overwritten at idx
+func (b *Buffer) release(idx int) {
+	x := idx % b.width
+	if b.chars[idx] == WIDE_CONTINUATION && x > 0 {
+		b.chars[idx-1] = ' '
+		b.clusters[idx-1] = ""
+	}
+	if x < b.width-1 && b.chars[idx+1] == WIDE_CONTINUATION {
+		b.chars[idx+1] = ' '
+	}
+}
+
+// setCluster writes a grapheme cluster which takes w columns. The second
+// slot of a double width cluster is marked as continuation
+func (b *Buffer) setCluster(x, y int, cl string, w int, style int) {
+	if w <= 0 || x < 0 || y < 0 || x+w > b.width || y >= b.height || b.clipped(x, y, w) {
+		return
+	}
+	idx := y*b.width + x
+	for i := 0; i < w; i++ {
+		b.release(idx + i)
+	}
+	style = b.withFill(style, b.fills[idx])
+	r, size := utf8.DecodeRuneInString(cl)
+	b.chars[idx] = r
+	b.styles[idx] = style
+	b.clusters[idx] = ""
+	if size < len(cl) {
+		b.clusters[idx] = cl
+	}
+	if w == 2 {
+		b.chars[idx+1] = WIDE_CONTINUATION
+		b.styles[idx+1] = style
+		b.clusters[idx+1] = ""
+	}
+}
+
+// Cell returns the grapheme cluster at the position. The second column
+// of a double width character is returned as empty string
+func (b *Buffer) Cell(x, y int) string {
+	if x < 0 || y < 0 || x >= b.width || y >= b.height {
+		return ""
+	}
+	idx := y*b.width + x
+	if b.clusters[idx] != "" {
+		return b.clusters[idx]
+	}
+	if b.chars[idx] == WIDE_CONTINUATION {
+		return ""
+	}
+	return string(b.chars[idx])
+}
@@ -0,0 +1,69 @@
+package imgui
+
+import (
+	"testing"
+
+	"github.com/alecthomas/assert"
+	"github.com/amecky/table/table"
+)
+
+func TestInternalLen(t *testing.T) {
+	assert.Equal(t, 5, internalLen("Hello"))
+	assert.Equal(t, 4, internalLen("日本"))
+	assert.Equal(t, 2, internalLen("👍🏽"))
+	assert.Equal(t, 4, internalLen("café"))
+	assert.Equal(t, 2, internalLen("🇩🇪"))
+}
+
+func TestFormatStringWide(t *testing.T) {
+	assert.Equal(t, "日本  |", formatString("日本", 6, table.AlignLeft)+"|")
+	assert.Equal(t, "  日本|", formatString("日本", 6, table.AlignRight)+"|")
+	assert.Equal(t, "café  |", formatString("café", 6, table.AlignLeft)+"|")
+}
+
+func TestSplitWidth(t *testing.T) {
+	head, rest := splitWidth("日本語", 3)
+	assert.Equal(t, "日", head)
+	assert.Equal(t, "本語", rest)
+	head, rest = splitWidth("cafés", 4)
+	assert.Equal(t, "café", head)
+	assert.Equal(t, "s", rest)
+	head, _ = splitWidthAtLeast("日本", 1)
+	assert.Equal(t, "日", head)
+	assert.Equal(t, "日本…", truncate("日本語です", 6))
+}
+
+func TestWideCells(t *testing.T) {
+	b := NewBuffer(6, 1)
+	b.Clear()
+	b.Set(0, 0, '日', 0)
+	b.setCluster(2, 0, "é", 1, 0)
+	assert.Equal(t, "日", b.Cell(0, 0))
+	assert.Equal(t, "", b.Cell(1, 0))
+	assert.Equal(t, "é", b.Cell(2, 0))
+	// a wide character does not fit into the last column
+	b.Set(5, 0, '本', 0)
+	assert.Equal(t, " ", b.Cell(5, 0))
+	// overwriting half of a wide character removes it
+	b.Set(1, 0, 'x', 0)
+	assert.Equal(t, " ", b.Cell(0, 0))
+	assert.Equal(t, "x", b.Cell(1, 0))
+}
+
+func TestWideText(t *testing.T) {
+	gui := NewGUI(12, 3)
+	gui.Begin()
+	gui.StartRow()
+	gui.StartCell()
+	gui.Text("日本")
+	gui.EndCell()
+	gui.EndRow()
+	gui.End()
+	b := gui.buffer
+	c := b.cells[0]
+	// two columns per character, padding and the border
+	assert.Equal(t, 6, c.w)
+	assert.Equal(t, "日", b.Cell(c.x, c.y))
+	assert.Equal(t, "本", b.Cell(c.x+2, c.y))
+	assert.Equal(t, "│", b.Cell(c.x+c.w-1, c.y))
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	imgui "imgui/gui"
 	"log"
+	"math"
 
 	"github.com/amecky/table/table"
 )
@@ -54,6 +55,32 @@ func (tv *TableView) Render(gui *imgui.GUI) {
 	gui.EndRow()
 }
 
+// samplePrices creates a fake price history for the selected intervall
+func samplePrices(intervall, count int) []float64 {
+	ret := make([]float64, count)
+	for i := range ret {
+		ret[i] = 100.0 + 10.0*math.Sin(float64(i*(intervall+1))*0.1) + float64(i)*0.2
+	}
+	return ret
+}
+
+// sampleCandles builds candles out of the fake price history
+func sampleCandles(prices []float64) []imgui.Candle {
+	ret := make([]imgui.Candle, 0, len(prices))
+	for i := 1; i < len(prices); i++ {
+		o := prices[i-1]
+		c := prices[i]
+		ret = append(ret, imgui.Candle{
+			Time:  fmt.Sprintf("#%d", i),
+			Open:  o,
+			High:  math.Max(o, c) + 0.5,
+			Low:   math.Min(o, c) - 0.5,
+			Close: c,
+		})
+	}
+	return ret
+}
+
 type TickerView struct {
 	selectedIntervall int
 	steps             int
@@ -80,6 +107,8 @@ func (m *TickerView) Render(gui *imgui.GUI) {
 			m.selectedIntervall = len(INTERVALLS) - 1
 		}
 	}
+	prices := samplePrices(m.selectedIntervall, 60)
+	gui.Sparkline(prices, 20)
 	m.steps = gui.IntSlider("Num:", 0, 100, m.steps, 10)
 	m.radio = gui.Checbox("Toggle Me", m.radio)
 	if gui.Button("Reload") {
@@ -89,6 +118,15 @@ func (m *TickerView) Render(gui *imgui.GUI) {
 	gui.EndCell()
 	gui.EndRow()
 
+	gui.StartRow()
+	gui.StartCellWithHeader("Price " + INTERVALLS[m.selectedIntervall])
+	gui.LineChart("", []imgui.Series{{Name: "Close", Values: prices}}, 40, 8)
+	gui.EndCell()
+	gui.StartCellWithHeader("Candles")
+	gui.CandleChart("candles", sampleCandles(prices), 40, 10)
+	gui.EndCell()
+	gui.EndRow()
+
 	gui.StartRow()
 	gui.StartCell()
 	m.input, m.inputState = gui.Input("Input:", m.input, m.inputState, 30)